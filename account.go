@@ -1,13 +1,10 @@
 package caip
 
 import (
-	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -23,10 +20,6 @@ type AccountID struct {
 	Address string  `json:"account_address"`
 }
 
-var (
-	accountRegex = regexp.MustCompile("[a-zA-Z0-9]{1,64}")
-)
-
 func NewAccountID(chainID ChainID, address string) (AccountID, error) {
 	aID := AccountID{chainID, address}
 	if err := aID.Validate(); err != nil {
@@ -41,38 +34,135 @@ func UnsafeAccountID(chainID ChainID, address string) AccountID {
 }
 
 func (c AccountID) Validate() error {
-	if err := c.ChainID.Validate(); err != nil {
+	g := c.ChainID.grammar()
+	g = g.set(accountAddressOK, validAccountAddress(c.Address))
+	return c.validateGrammar(g)
+}
+
+func (c AccountID) validateGrammar(g grammar) error {
+	if err := c.ChainID.validateGrammar(0, g); err != nil {
 		return err
 	}
 
-	if ok := accountRegex.Match([]byte(c.Address)); !ok {
-		return errors.New("namespace does not match spec")
+	offset := len(c.ChainID.Namespace) + len(c.ChainID.Reference) + 2
+	if g&accountAddressOK == 0 {
+		return &ValidationError{AccountIDKind, "account_address", c.Address, offset, ErrAddressInvalid}
+	}
+
+	if ns, ok := LookupNamespace(c.ChainID.Namespace); ok {
+		if err := ns.ValidateAddress(c.ChainID, c.Address); err != nil {
+			return &ValidationError{AccountIDKind, "account_address", c.Address, offset, namespaceError(ErrAddressInvalid, err)}
+		}
 	}
 
 	return nil
 }
 
-func (c AccountID) String() string {
+// DecodedAddress returns the address with any percent-encoded characters
+// decoded.
+func (c AccountID) DecodedAddress() (string, error) {
+	return UnescapeAddress(c.Address)
+}
+
+// Normalize returns the account id with the address in the form preferred by
+// its namespace. Normalized account ids of the same account are ==, so they
+// can be used as map keys.
+func (c AccountID) Normalize() (AccountID, error) {
 	if err := c.Validate(); err != nil {
-		panic(err)
+		return AccountID{}, err
 	}
+
+	ns, ok := LookupNamespace(c.ChainID.Namespace)
+	if !ok {
+		return c, nil
+	}
+
+	address, err := ns.NormalizeAddress(c.ChainID, c.Address)
+	if err != nil {
+		return AccountID{}, err
+	}
+
+	return AccountID{c.ChainID, address}, nil
+}
+
+// String returns the account id as it is, see ChainID.String.
+func (c AccountID) String() string {
 	return c.ChainID.String() + ":" + c.Address
 }
 
+// Canonical returns the CAIP string form of a valid account id with its
+// address normalized, e.g. EIP-55 checksummed for eip155.
+func (c AccountID) Canonical() (string, error) {
+	n, err := c.Normalize()
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// normalized returns the normalized account id, or the account id itself if
+// it is invalid.
+func (c AccountID) normalized() AccountID {
+	n, err := c.Normalize()
+	if err != nil {
+		return c
+	}
+	return n
+}
+
+// Equal reports whether both account ids refer to the same account, e.g.
+// ignoring the case of eip155 addresses. Invalid account ids are compared as
+// they are.
+func (c AccountID) Equal(other AccountID) bool {
+	return c.normalized() == other.normalized()
+}
+
+// Compare orders normalized account ids by chain id and then address,
+// returning -1, 0 or 1. It is consistent with Equal.
+func (c AccountID) Compare(other AccountID) int {
+	a, b := c.normalized(), other.normalized()
+	if n := a.ChainID.Compare(b.ChainID); n != 0 {
+		return n
+	}
+	return strings.Compare(a.Address, b.Address)
+}
+
+// Format implements fmt.Formatter. %+v prints the components of the account id.
+func (c AccountID) Format(f fmt.State, verb rune) {
+	type accountIDFormat AccountID
+	format(f, verb, "AccountID", c.String(), accountIDFormat(c))
+}
+
 func (c *AccountID) Parse(s string) error {
-	split := strings.SplitN(s, ":", 3)
-	if len(split) != 3 {
-		return fmt.Errorf("invalid account id: %s", s)
+	var g grammar
+	nsEnd, ok, found := component(s, 0, ':', ':', namespaceChars, 3, 8)
+	if !found {
+		return malformedError(AccountIDKind, s)
 	}
+	g = g.set(chainNamespaceOK, ok)
 
-	*c = AccountID{ChainID{split[0], split[1]}, split[2]}
-	if err := c.Validate(); err != nil {
+	refEnd, ok, found := component(s, nsEnd+1, ':', ':', chainReferenceChars, 1, 32)
+	if !found {
+		return malformedError(AccountIDKind, s)
+	}
+	g = g.set(chainReferenceOK, ok)
+	g = g.set(accountAddressOK, validAccountAddress(s[refEnd+1:]))
+
+	*c = AccountID{ChainID{s[:nsEnd], s[nsEnd+1 : refEnd]}, s[refEnd+1:]}
+	if err := c.validateGrammar(g); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// ParseBytes is like Parse but does not allocate: the components of the
+// account id share memory with b, which must not be modified afterwards. Use
+// Parse(string(b)) when b is reused.
+func (c *AccountID) ParseBytes(b []byte) error {
+	return c.Parse(unsafeString(b))
+}
+
 func (c *AccountID) ParseX(s string) {
 	if err := c.Parse(s); err != nil {
 		panic(err)
@@ -80,6 +170,10 @@ func (c *AccountID) ParseX(s string) {
 }
 
 func (c *AccountID) UnmarshalJSON(data []byte) error {
+	if ok, err := unmarshalJSONString(data, c.Parse); ok {
+		return err
+	}
+
 	type AccountIDAlias AccountID
 	ca := (*AccountIDAlias)(c)
 	if err := json.Unmarshal(data, &ca); err != nil {
@@ -103,21 +197,33 @@ func (c AccountID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ca)
 }
 
+// MarshalText returns the string form of a valid account id as it was given,
+// without normalizing it; see Canonical.
+func (c AccountID) MarshalText() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(c.String()), nil
+}
+
+func (c *AccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, c.Parse)
+}
+
 func (c AccountID) Value() (driver.Value, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
 	return c.String(), nil
 }
 
 func (c *AccountID) Scan(src interface{}) error {
-	var i sql.NullString
-	if err := i.Scan(src); err != nil {
-		return fmt.Errorf("scanning account id: %w", err)
-	}
-
-	if !i.Valid {
-		return nil
+	s, err := scanString(AccountIDKind, src)
+	if err != nil {
+		return err
 	}
 
-	if err := c.Parse(i.String); err != nil {
+	if err := c.Parse(s); err != nil {
 		return err
 	}
 
@@ -125,17 +231,52 @@ func (c *AccountID) Scan(src interface{}) error {
 }
 
 func (c AccountID) MarshalGQL(w io.Writer) {
-	fmt.Fprint(w, strconv.Quote(strings.ToUpper(c.String())))
+	marshalGQL(w, c.String())
 }
 
 func (c *AccountID) UnmarshalGQL(v interface{}) error {
-	if id, ok := v.(string); ok {
-		if err := c.Parse(id); err != nil {
-			return fmt.Errorf("unmarshalling account id: %w", err)
+	return unmarshalGQL(AccountIDKind, v, c.Parse)
+}
+
+// EscapeAddress percent-encodes every byte of s that is not allowed in a
+// CAIP-10 account address.
+func EscapeAddress(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; isAddressChar(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
 		}
 	}
+	return b.String()
+}
 
-	return nil
+func UnescapeAddress(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			return "", fmt.Errorf("invalid percent-encoding at offset %d: %s", i, s)
+		}
+
+		v, _ := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		b.WriteByte(byte(v))
+		i += 2
+	}
+	return b.String(), nil
+}
+
+func isAddressChar(c byte) bool {
+	return c == '-' || c == '.' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
 }
 
 type EVMAccountID struct {
@@ -163,8 +304,11 @@ func (c *EVMAccountID) Parse(s string) error {
 		return err
 	}
 
-	c.AccountID.Address = common.HexToAddress(c.AccountID.Address).Hex()
+	if err := c.Validate(); err != nil {
+		return err
+	}
 
+	c.AccountID.Address = common.HexToAddress(c.AccountID.Address).Hex()
 	return nil
 }
 
@@ -180,6 +324,92 @@ func (a EVMAccountID) Validate() error {
 	return a.AccountID.Validate()
 }
 
+func (a *EVMAccountID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *EVMAccountID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *EVMAccountID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AccountID.UnmarshalJSON, a)
+}
+
+func (a *EVMAccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *EVMAccountID) Scan(src interface{}) error {
+	return scanParse(AccountIDKind, src, a.Parse)
+}
+
+func (a *EVMAccountID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AccountIDKind, v, a.Parse)
+}
+
 func (a EVMAccountID) Address() common.Address {
 	return common.HexToAddress(a.AccountID.Address)
 }
+
+// LegacyAccountID is an account id restricted to the original CAIP-10 grammar
+// of up to 64 alphanumeric address characters, for systems that predate the
+// current grammar.
+type LegacyAccountID struct {
+	AccountID
+}
+
+func NewLegacyAccountID(chainID ChainID, address string) (LegacyAccountID, error) {
+	aID := LegacyAccountID{AccountID{chainID, address}}
+	if err := aID.Validate(); err != nil {
+		return LegacyAccountID{}, err
+	}
+
+	return aID, nil
+}
+
+func (a LegacyAccountID) Validate() error {
+	if err := a.AccountID.Validate(); err != nil {
+		return err
+	}
+
+	if !validLegacyAccountAddress(a.AccountID.Address) {
+		offset := len(a.ChainID.Namespace) + 1 + len(a.ChainID.Reference) + 1
+		return &ValidationError{AccountIDKind, "account_address", a.AccountID.Address, offset, ErrAddressInvalid}
+	}
+
+	return nil
+}
+
+// Parse parses an account id and rejects addresses outside the legacy grammar.
+func (a *LegacyAccountID) Parse(s string) error {
+	return parseTyped(s, a.AccountID.Parse, a)
+}
+
+func (a *LegacyAccountID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *LegacyAccountID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *LegacyAccountID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AccountID.UnmarshalJSON, a)
+}
+
+func (a *LegacyAccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *LegacyAccountID) Scan(src interface{}) error {
+	return scanParse(AccountIDKind, src, a.Parse)
+}
+
+func (a *LegacyAccountID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AccountIDKind, v, a.Parse)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -21,8 +22,8 @@ func TestAccountID(t *testing.T) {
 		// Cosmos Hub
 		id: "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
 	}, {
-		// Kusama network
-		id: "polkadot:b0a8d493285c2df73290dfb7e61f870f:5hmuyxw9xdgbpptgypokw4thfyoe3ryenebr381z9iaegmfy",
+		// Kusama network (the lowercased address of the CAIP-10 example is not valid SS58)
+		id: "polkadot:b0a8d493285c2df73290dfb7e61f870f:HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
 	}, {
 		// Dummy max length (64+1+8+1+32 = 106 chars/bytes)
 		id: "chainstd:8c3444cf8970a9e41a706fab93e7a6c4:6d9b0b4b9994e8a6afbd3dc3ed983cd51c755afb27cd1dc7825ef59c134a39f7",
@@ -126,10 +127,16 @@ func TestInvalidEVMAccountID(t *testing.T) {
 		// Ethereum mainnet
 		id:  "cosmos:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdd",
 		err: fmt.Errorf("invalid chain namespace: %s", "cosmos"),
+	}, {
+		id:  "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		err: fmt.Errorf("invalid eth address: %s", "cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0"),
+	}, {
+		id:  "foobar:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb",
+		err: fmt.Errorf("invalid chain namespace: %s", "foobar"),
 	}} {
 		a := EVMAccountID{}
-		if err := a.Parse(tc.id); err != nil {
-			t.Errorf("Failed to parse account id")
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse account id should error")
 		}
 
 		if a.String() != tc.id {
@@ -155,3 +162,91 @@ func TestInvalidEVMAccountID(t *testing.T) {
 		}
 	}
 }
+
+func TestAccountIDAddressGrammar(t *testing.T) {
+	for _, tc := range []struct {
+		id     string
+		legacy bool
+	}{{
+		// Hedera account
+		id: "hedera:mainnet:0.0.1234",
+	}, {
+		// NEAR named account
+		id: "near:mainnet:alice.near",
+	}, {
+		// Percent-encoded address
+		id: "chainstd:8c3444cf8970a9e41a706fab93e7a6c4:user%40example",
+	}, {
+		// Dummy max length (128 chars)
+		id: "chainstd:8c3444cf8970a9e41a706fab93e7a6c4:" + strings.Repeat("a", 128),
+	}, {
+		// Dummy max legacy length (64 chars)
+		id:     "chainstd:8c3444cf8970a9e41a706fab93e7a6c4:" + strings.Repeat("a", 64),
+		legacy: true,
+	}} {
+		a := AccountID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse account id: %v", err)
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		err := LegacyAccountID{a}.Validate()
+		if (err == nil) != tc.legacy {
+			t.Errorf("%s: unexpected legacy validation result: %v", tc.id, err)
+		}
+
+		if !tc.legacy && !errors.Is(err, ErrAddressInvalid) {
+			t.Errorf("%s: expected address error, got: %v", tc.id, err)
+		}
+
+		obj, _ := json.Marshal(a)
+		quoted, _ := json.Marshal(tc.id)
+		for name, decode := range map[string]func(*LegacyAccountID) error{
+			"parse":       func(l *LegacyAccountID) error { return l.Parse(tc.id) },
+			"text":        func(l *LegacyAccountID) error { return l.UnmarshalText([]byte(tc.id)) },
+			"json string": func(l *LegacyAccountID) error { return json.Unmarshal(quoted, l) },
+			"json object": func(l *LegacyAccountID) error { return json.Unmarshal(obj, l) },
+			"scan":        func(l *LegacyAccountID) error { return l.Scan(tc.id) },
+			"gql":         func(l *LegacyAccountID) error { return l.UnmarshalGQL(tc.id) },
+		} {
+			if err := decode(&LegacyAccountID{}); (err == nil) != tc.legacy {
+				t.Errorf("%s: unexpected legacy %s result: %v", tc.id, name, err)
+			}
+		}
+	}
+
+	if err := new(AccountID).Parse("chainstd:8c3444cf8970a9e41a706fab93e7a6c4:" + strings.Repeat("a", 129)); !errors.Is(err, ErrAddressInvalid) {
+		t.Errorf("expected address error, got: %v", err)
+	}
+}
+
+func TestEscapeAddress(t *testing.T) {
+	raw := "user@example_1"
+	escaped := EscapeAddress(raw)
+	if escaped != "user%40example%5F1" {
+		t.Fatalf("Escaped address invalid: %s", escaped)
+	}
+
+	a, err := NewAccountID(ChainID{"chainstd", "8c3444cf8970a9e41a706fab93e7a6c4"}, escaped)
+	if err != nil {
+		t.Fatalf("Failed to create account id from escaped address: %v", err)
+	}
+
+	decoded, err := a.DecodedAddress()
+	if err != nil {
+		t.Fatalf("Failed to decode address: %v", err)
+	}
+
+	if decoded != raw {
+		t.Errorf("Decoded address invalid: %s", decoded)
+	}
+
+	for _, s := range []string{"%", "%4", "%zz", "abc%4"} {
+		if _, err := UnescapeAddress(s); err == nil {
+			t.Errorf("%s: unescape should error", s)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package caip
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrAssetMismatch = errors.New("amounts of different assets")
+)
+
+// Amount is a quantity of an asset in base units. Its string form is the
+// decimal value with exactly Decimals fractional digits followed by the asset
+// id, e.g. "1.500000 eip155:1/erc20:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48".
+type Amount struct {
+	AssetID  AssetID
+	Units    *big.Int
+	Decimals int
+}
+
+func NewAmount(assetID AssetID, units *big.Int, decimals int) (Amount, error) {
+	a := Amount{assetID, units, decimals}
+	if err := a.Validate(); err != nil {
+		return Amount{}, err
+	}
+
+	return a, nil
+}
+
+// ParseAmount parses a decimal string such as "1.5" into base units of an
+// asset with the given number of decimals.
+func ParseAmount(assetID AssetID, s string, decimals int) (Amount, error) {
+	if decimals < 0 {
+		return Amount{}, fmt.Errorf("invalid decimals: %d", decimals)
+	}
+
+	digits := strings.TrimPrefix(s, "-")
+	split := strings.SplitN(digits, ".", 2)
+	if len(split) == 2 && len(split[1]) > decimals {
+		return Amount{}, fmt.Errorf("invalid amount: %s has more than %d decimals", s, decimals)
+	}
+
+	frac := ""
+	if len(split) == 2 {
+		frac = split[1]
+	}
+
+	if split[0] == "" || !isDigits(split[0]) || (len(split) == 2 && (frac == "" || !isDigits(frac))) {
+		return Amount{}, fmt.Errorf("invalid amount: %s", s)
+	}
+
+	units, _ := new(big.Int).SetString(split[0]+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if strings.HasPrefix(s, "-") {
+		units.Neg(units)
+	}
+
+	return NewAmount(assetID, units, decimals)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (a Amount) Validate() error {
+	if a.Decimals < 0 {
+		return fmt.Errorf("invalid decimals: %d", a.Decimals)
+	}
+
+	return a.AssetID.Validate()
+}
+
+func (a Amount) units() *big.Int {
+	if a.Units == nil {
+		return new(big.Int)
+	}
+	return a.Units
+}
+
+// Decimal formats the value with exactly Decimals fractional digits.
+func (a Amount) Decimal() string {
+	v := a.units()
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= a.Decimals {
+		digits = strings.Repeat("0", a.Decimals-len(digits)+1) + digits
+	}
+
+	s := digits
+	if a.Decimals > 0 {
+		s = digits[:len(digits)-a.Decimals] + "." + digits[len(digits)-a.Decimals:]
+	}
+
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// FormatDecimal formats the value without trailing fractional zeros.
+func (a Amount) FormatDecimal() string {
+	s := a.Decimal()
+	if a.Decimals > 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+func (a Amount) String() string {
+	return a.Decimal() + " " + a.AssetID.String()
+}
+
+func (a *Amount) Parse(s string) error {
+	split := strings.SplitN(s, " ", 2)
+	if len(split) != 2 {
+		return fmt.Errorf("invalid amount: %s", s)
+	}
+
+	aID := AssetID{}
+	if err := aID.Parse(split[1]); err != nil {
+		return err
+	}
+
+	decimals := 0
+	if i := strings.IndexByte(split[0], '.'); i >= 0 {
+		decimals = len(split[0]) - i - 1
+	}
+
+	amount, err := ParseAmount(aID, split[0], decimals)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func (a *Amount) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a Amount) Sign() int {
+	return a.units().Sign()
+}
+
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// scaled returns the units of the amount with decimals fractional digits,
+// which must not be fewer than its own.
+func (a Amount) scaled(decimals int) *big.Int {
+	if decimals == a.Decimals {
+		return a.units()
+	}
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-a.Decimals)), nil)
+	return new(big.Int).Mul(a.units(), exp)
+}
+
+// align returns the units of two amounts of the same asset scaled to the
+// larger of their decimals.
+func (a Amount) align(b Amount) (x, y *big.Int, decimals int, err error) {
+	if !a.AssetID.Equal(b.AssetID) {
+		return nil, nil, 0, fmt.Errorf("%w: %s and %s", ErrAssetMismatch, a.AssetID, b.AssetID)
+	}
+
+	decimals = a.Decimals
+	if b.Decimals > decimals {
+		decimals = b.Decimals
+	}
+	return a.scaled(decimals), b.scaled(decimals), decimals, nil
+}
+
+// Add returns the sum of two amounts of the same asset, with the larger of
+// their decimals.
+func (a Amount) Add(b Amount) (Amount, error) {
+	x, y, decimals, err := a.align(b)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return Amount{a.AssetID, new(big.Int).Add(x, y), decimals}, nil
+}
+
+// Sub returns the difference of two amounts of the same asset, with the
+// larger of their decimals.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	x, y, decimals, err := a.align(b)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return Amount{a.AssetID, new(big.Int).Sub(x, y), decimals}, nil
+}
+
+// Cmp compares the values of two amounts of the same asset, see big.Int.Cmp.
+func (a Amount) Cmp(b Amount) (int, error) {
+	x, y, _, err := a.align(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return x.Cmp(y), nil
+}
+
+type amountJSON struct {
+	AssetID  AssetID `json:"asset_id"`
+	Units    string  `json:"units"`
+	Decimals int     `json:"decimals"`
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if ok, err := unmarshalJSONString(data, a.Parse); ok {
+		return err
+	}
+
+	var aj amountJSON
+	if err := json.Unmarshal(data, &aj); err != nil {
+		return err
+	}
+
+	units, ok := new(big.Int).SetString(aj.Units, 10)
+	if !ok {
+		return fmt.Errorf("invalid amount units: %s", aj.Units)
+	}
+
+	amount, err := NewAmount(aj.AssetID, units, aj.Decimals)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(amountJSON{a.AssetID, a.units().String(), a.Decimals})
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(src interface{}) error {
+	s, err := scanString("amount", src)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Parse(s); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a Amount) MarshalGQL(w io.Writer) {
+	marshalGQL(w, a.String())
+}
+
+func (a *Amount) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL("amount", v, a.Parse)
+}
@@ -0,0 +1,170 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestAmount(t *testing.T) {
+	eth := AssetID{}
+	eth.ParseX("eip155:1/slip44:60")
+
+	usdc := AssetID{}
+	usdc.ParseX("eip155:1/erc20:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+	for _, tc := range []struct {
+		asset    AssetID
+		decimal  string
+		decimals int
+		units    string
+		str      string
+		format   string
+	}{{
+		asset:    eth,
+		decimal:  "1.5",
+		decimals: 18,
+		units:    "1500000000000000000",
+		str:      "1.500000000000000000 eip155:1/slip44:60",
+		format:   "1.5",
+	}, {
+		asset:    eth,
+		decimal:  "0.000000000000000001",
+		decimals: 18,
+		units:    "1",
+		str:      "0.000000000000000001 eip155:1/slip44:60",
+		format:   "0.000000000000000001",
+	}, {
+		asset:    usdc,
+		decimal:  "-42",
+		decimals: 6,
+		units:    "-42000000",
+		str:      "-42.000000 eip155:1/erc20:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		format:   "-42",
+	}, {
+		asset:    eth,
+		decimal:  "7",
+		decimals: 0,
+		units:    "7",
+		str:      "7 eip155:1/slip44:60",
+		format:   "7",
+	}} {
+		a, err := ParseAmount(tc.asset, tc.decimal, tc.decimals)
+		if err != nil {
+			t.Fatalf("Failed to parse amount: %v", err)
+		}
+
+		if a.Units.String() != tc.units {
+			t.Errorf("%s: units invalid: %s", tc.decimal, a.Units)
+		}
+
+		if a.String() != tc.str {
+			t.Errorf("%s: failed to serialize amount to string: %s", tc.decimal, a)
+		}
+
+		if a.FormatDecimal() != tc.format {
+			t.Errorf("%s: formatted amount invalid: %s", tc.decimal, a.FormatDecimal())
+		}
+
+		a2 := Amount{}
+		if err := a2.Parse(a.String()); err != nil {
+			t.Fatalf("Failed to parse amount string: %v", err)
+		}
+
+		if a2.String() != a.String() || a2.Decimals != a.Decimals {
+			t.Errorf("Parsed amount invalid: %s", a2)
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a2 = Amount{}
+		if err := json.Unmarshal(b, &a2); err != nil {
+			t.Errorf("Failed to unmarshal to json: %v", err)
+		}
+
+		if a2.String() != a.String() {
+			t.Errorf("Unmarshalled amount invalid")
+		}
+
+		a2 = Amount{}
+		if err := a2.Scan(a.String()); err != nil {
+			t.Errorf("Scanning value from sql.NullString")
+		}
+
+		if a2.String() != a.String() {
+			t.Errorf("Scanned value not valid")
+		}
+	}
+
+	for _, s := range []string{"", "1.", ".5", "1.2.3", "abc", "1.0000000000000000001", "--1"} {
+		if _, err := ParseAmount(eth, s, 18); err == nil {
+			t.Errorf("%q: parse amount should error", s)
+		}
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	eth := AssetID{}
+	eth.ParseX("eip155:1/slip44:60")
+
+	matic := AssetID{}
+	matic.ParseX("eip155:137/slip44:966")
+
+	a, _ := ParseAmount(eth, "1.5", 18)
+	b, _ := ParseAmount(eth, "0.25", 18)
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("Failed to add amounts: %v", err)
+	}
+
+	if sum.FormatDecimal() != "1.75" {
+		t.Errorf("Sum invalid: %s", sum)
+	}
+
+	diff, err := b.Sub(a)
+	if err != nil {
+		t.Fatalf("Failed to subtract amounts: %v", err)
+	}
+
+	if diff.FormatDecimal() != "-1.25" || diff.Sign() >= 0 {
+		t.Errorf("Difference invalid: %s", diff)
+	}
+
+	if c, err := a.Cmp(b); err != nil || c != 1 {
+		t.Errorf("Comparison invalid: %d, %v", c, err)
+	}
+
+	if a.Units.String() != "1500000000000000000" {
+		t.Errorf("Arithmetic should not modify operands: %s", a.Units)
+	}
+
+	m, _ := NewAmount(matic, big.NewInt(1), 18)
+	if _, err := a.Add(m); !errors.Is(err, ErrAssetMismatch) {
+		t.Errorf("expected asset mismatch, got: %v", err)
+	}
+
+	// Amounts with different decimals are rescaled
+	x, y := Amount{}, Amount{}
+	x.ParseX("1.5 eip155:1/slip44:60")
+	y.ParseX("1.50 eip155:1/slip44:60")
+	if c, err := x.Cmp(y); err != nil || c != 0 {
+		t.Errorf("Comparison invalid: %d, %v", c, err)
+	}
+
+	if sum, err := x.Add(y); err != nil || sum.String() != "3.00 eip155:1/slip44:60" {
+		t.Errorf("Sum invalid: %s, %v", sum, err)
+	}
+
+	if diff, err := a.Sub(Amount{eth, big.NewInt(5), 1}); err != nil || diff.FormatDecimal() != "1" || diff.Decimals != 18 {
+		t.Errorf("Difference invalid: %s, %v", diff, err)
+	}
+
+	if !(Amount{AssetID: eth, Decimals: 18}).IsZero() {
+		t.Errorf("Amount without units should be zero")
+	}
+}
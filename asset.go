@@ -1,14 +1,11 @@
 package caip
 
 import (
-	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,15 +15,13 @@ type AssetID struct {
 	ChainID   ChainID `json:"chain_id"`
 	Namespace string  `json:"asset_namespace"`
 	Reference string  `json:"asset_reference"`
+	TokenID   string  `json:"token_id,omitempty"`
 }
 
-var (
-	assetNamespaceRegex = regexp.MustCompile("[-a-z0-9]{3,8}")
-	assetReferenceRegex = regexp.MustCompile("[-a-zA-Z0-9]{1,64}")
-)
-
+// NewAssetID creates an asset id from its components, a token id can be
+// appended to the reference as in its string form ("0x06012c8c.../771769").
 func NewAssetID(chainID ChainID, namespace, reference string) (AssetID, error) {
-	aID := AssetID{chainID, namespace, reference}
+	aID := UnsafeAssetID(chainID, namespace, reference)
 	if err := aID.Validate(); err != nil {
 		return AssetID{}, err
 	}
@@ -35,52 +30,214 @@ func NewAssetID(chainID ChainID, namespace, reference string) (AssetID, error) {
 }
 
 func UnsafeAssetID(chainID ChainID, namespace, reference string) AssetID {
-	return AssetID{chainID, namespace, reference}
+	reference, tokenID := splitTokenID(reference)
+	return AssetID{chainID, namespace, reference, tokenID}
+}
+
+// splitTokenID splits the token id off a reference. A trailing separator is
+// left in the reference so that the empty token id fails validation.
+func splitTokenID(reference string) (string, string) {
+	i := strings.IndexByte(reference, '/')
+	if i < 0 || i == len(reference)-1 {
+		return reference, ""
+	}
+	return reference[:i], reference[i+1:]
+}
+
+func joinTokenID(reference, tokenID string) string {
+	if tokenID == "" {
+		return reference
+	}
+	return reference + "/" + tokenID
 }
 
 func (a AssetID) Validate() error {
-	if ok := assetNamespaceRegex.Match([]byte(a.Namespace)); !ok {
-		return errors.New("asset namespace does not match spec")
+	return a.validate(AssetIDKind)
+}
+
+func (a AssetID) validate(kind Kind) error {
+	return a.validateGrammar(kind, a.grammar())
+}
+
+func (a AssetID) grammar() grammar {
+	g := a.ChainID.grammar()
+	g = g.set(assetNamespaceOK, validAssetNamespace(a.Namespace))
+	g = g.set(assetReferenceOK, validAssetReference(a.Reference))
+	g = g.set(tokenIDOK, a.TokenID == "" || validTokenID(a.TokenID))
+	return g
+}
+
+func (a AssetID) validateGrammar(kind Kind, g grammar) error {
+	if err := a.ChainID.validateGrammar(0, g); err != nil {
+		return err
+	}
+
+	offset := len(a.ChainID.Namespace) + len(a.ChainID.Reference) + 2
+	if g&assetNamespaceOK == 0 {
+		return &ValidationError{kind, "asset_namespace", a.Namespace, offset, ErrNamespaceInvalid}
+	}
+
+	offset += len(a.Namespace) + 1
+	if g&assetReferenceOK == 0 {
+		// An empty token id, as left in the reference by splitTokenID
+		if ref := strings.TrimSuffix(a.Reference, "/"); kind == AssetIDKind && ref != a.Reference && validAssetReference(ref) {
+			return &ValidationError{kind, "token_id", "", offset + len(a.Reference), ErrTokenIDInvalid}
+		}
+		return &ValidationError{kind, "asset_reference", a.Reference, offset, ErrReferenceInvalid}
+	}
+
+	tokenOffset := offset + len(a.Reference) + 1
+	if g&tokenIDOK == 0 {
+		return &ValidationError{kind, "token_id", a.TokenID, tokenOffset, ErrTokenIDInvalid}
 	}
 
-	if ok := assetReferenceRegex.Match([]byte(a.Reference)); !ok {
-		return errors.New("asset reference does not match spec")
+	if ns, ok := LookupNamespace(a.ChainID.Namespace); ok {
+		if err := ns.ValidateAsset(a); err != nil {
+			if errors.Is(err, ErrTokenIDInvalid) {
+				return &ValidationError{kind, "token_id", a.TokenID, tokenOffset, err}
+			}
+			return &ValidationError{kind, "asset_reference", a.Reference, offset, namespaceError(ErrReferenceInvalid, err)}
+		}
 	}
 
 	return nil
 }
 
+func (a AssetID) AssetType() AssetType {
+	return AssetType{a.ChainID, a.Namespace, a.Reference}
+}
+
+// String joins the components of the asset id, the token id only if it is
+// set. Like ChainID.String it does not validate them.
 func (a AssetID) String() string {
+	return a.ChainID.String() + "/" + a.Namespace + ":" + joinTokenID(a.Reference, a.TokenID)
+}
+
+// Canonical returns the CAIP string form of a valid asset id with its
+// reference and token id normalized.
+func (a AssetID) Canonical() (string, error) {
+	n, err := a.Normalize()
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// Normalize returns the asset id with the reference and token id in the form
+// preferred by its namespace, if the namespace implements AssetNormalizer.
+// Normalized asset ids of the same asset are ==, so they can be used as map
+// keys.
+func (a AssetID) Normalize() (AssetID, error) {
 	if err := a.Validate(); err != nil {
-		panic(err)
+		return AssetID{}, err
 	}
-	return a.ChainID.String() + "/" + a.Namespace + ":" + a.Reference
+
+	ns, ok := LookupNamespace(a.ChainID.Namespace)
+	if !ok {
+		return a, nil
+	}
+
+	n, ok := ns.(AssetNormalizer)
+	if !ok {
+		return a, nil
+	}
+
+	return n.NormalizeAsset(a)
+}
+
+// normalized returns the normalized asset id, or the asset id itself if it is
+// invalid.
+func (a AssetID) normalized() AssetID {
+	n, err := a.Normalize()
+	if err != nil {
+		return a
+	}
+	return n
+}
+
+// Equal reports whether both asset ids refer to the same asset. Invalid asset
+// ids are compared as they are.
+func (a AssetID) Equal(other AssetID) bool {
+	return a.normalized() == other.normalized()
+}
+
+// Compare orders normalized asset ids by asset type and then token id,
+// returning -1, 0 or 1. It is consistent with Equal.
+func (a AssetID) Compare(other AssetID) int {
+	x, y := a.normalized(), other.normalized()
+	if n := x.AssetType().compare(y.AssetType()); n != 0 {
+		return n
+	}
+	return strings.Compare(x.TokenID, y.TokenID)
+}
+
+// Format implements fmt.Formatter. %+v prints the components of the asset id.
+func (a AssetID) Format(f fmt.State, verb rune) {
+	type assetIDFormat AssetID
+	format(f, verb, "AssetID", a.String(), assetIDFormat(a))
 }
 
 func (a *AssetID) Parse(s string) error {
-	components := strings.SplitN(s, "/", 2)
-	if len(components) != 2 {
-		return fmt.Errorf("invalid asset id: %s", s)
+	g, chainID, namespace, refStart, ok := scanAssetType(s)
+	if !ok {
+		return malformedError(AssetIDKind, s)
 	}
 
-	cID := new(ChainID)
-	if err := cID.Parse(components[0]); err != nil {
-		return err
+	refEnd, ok, found := component(s, refStart, '/', '/', addressChars, 1, 128)
+	g = g.set(assetReferenceOK, ok)
+
+	tokenID := ""
+	if found {
+		tokenID = s[refEnd+1:]
 	}
+	g = g.set(tokenIDOK, !found || validTokenID(tokenID))
 
-	asset := strings.SplitN(components[1], ":", 2)
-	if len(asset) != 2 {
-		return fmt.Errorf("invalid asset id: %s", s)
+	// Keep the separator of an empty token id, as splitTokenID does
+	if found && tokenID == "" {
+		refEnd = len(s)
+		g &^= assetReferenceOK
 	}
 
-	*a = AssetID{*cID, asset[0], asset[1]}
-	if err := a.Validate(); err != nil {
+	*a = AssetID{chainID, namespace, s[refStart:refEnd], tokenID}
+	if err := a.validateGrammar(AssetIDKind, g); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// ParseBytes is like Parse but does not allocate: the components of the asset
+// id share memory with b, which must not be modified afterwards. Use
+// Parse(string(b)) when b is reused.
+func (a *AssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+// scanAssetType scans the chain id and asset namespace of an asset type or id
+// and returns the start of the asset reference, or false if s is malformed.
+func scanAssetType(s string) (g grammar, chainID ChainID, namespace string, refStart int, ok bool) {
+	nsEnd, ok, found := component(s, 0, ':', '/', namespaceChars, 3, 8)
+	if !found || s[nsEnd] != ':' {
+		return 0, ChainID{}, "", 0, false
+	}
+	g = g.set(chainNamespaceOK, ok)
+
+	refEnd, ok, found := component(s, nsEnd+1, '/', '/', chainReferenceChars, 1, 32)
+	if !found {
+		return 0, ChainID{}, "", 0, false
+	}
+	g = g.set(chainReferenceOK, ok)
+
+	assetNsEnd, ok, found := component(s, refEnd+1, ':', ':', namespaceChars, 3, 8)
+	if !found {
+		return 0, ChainID{}, "", 0, false
+	}
+	g = g.set(assetNamespaceOK, ok)
+
+	chainID = ChainID{s[:nsEnd], s[nsEnd+1 : refEnd]}
+	return g, chainID, s[refEnd+1 : assetNsEnd], assetNsEnd + 1, true
+}
+
 func (a *AssetID) ParseX(s string) {
 	if err := a.Parse(s); err != nil {
 		panic(err)
@@ -88,12 +245,21 @@ func (a *AssetID) ParseX(s string) {
 }
 
 func (a *AssetID) UnmarshalJSON(data []byte) error {
+	if ok, err := unmarshalJSONString(data, a.Parse); ok {
+		return err
+	}
+
 	type AssetIDAlias AssetID
 	aa := (*AssetIDAlias)(a)
 	if err := json.Unmarshal(data, &aa); err != nil {
 		return err
 	}
 
+	// Token ids used to be stored as part of the asset reference
+	if a.TokenID == "" {
+		a.Reference, a.TokenID = splitTokenID(a.Reference)
+	}
+
 	if err := a.Validate(); err != nil {
 		return err
 	}
@@ -111,21 +277,32 @@ func (a AssetID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ca)
 }
 
+// MarshalText validates the asset id and keeps the case of its reference.
+func (a AssetID) MarshalText() ([]byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(a.String()), nil
+}
+
+func (a *AssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
 func (a AssetID) Value() (driver.Value, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
 	return a.String(), nil
 }
 
 func (a *AssetID) Scan(src interface{}) error {
-	var i sql.NullString
-	if err := i.Scan(src); err != nil {
-		return fmt.Errorf("scanning account id: %w", err)
-	}
-
-	if !i.Valid {
-		return nil
+	s, err := scanString(AssetIDKind, src)
+	if err != nil {
+		return err
 	}
 
-	if err := a.Parse(i.String); err != nil {
+	if err := a.Parse(s); err != nil {
 		return err
 	}
 
@@ -133,17 +310,11 @@ func (a *AssetID) Scan(src interface{}) error {
 }
 
 func (a AssetID) MarshalGQL(w io.Writer) {
-	fmt.Fprint(w, strconv.Quote(strings.ToUpper(a.String())))
+	marshalGQL(w, a.String())
 }
 
 func (a *AssetID) UnmarshalGQL(v interface{}) error {
-	if id, ok := v.(string); ok {
-		if err := a.Parse(id); err != nil {
-			return fmt.Errorf("unmarshalling asset id: %w", err)
-		}
-	}
-
-	return nil
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
 }
 
 type EVMAssetID struct {
@@ -152,7 +323,7 @@ type EVMAssetID struct {
 }
 
 func NewEVMAssetID(chainID ChainID, namespace, reference string) (EVMAssetID, error) {
-	aID := EVMAssetID{AssetID: AssetID{chainID, namespace, reference}}
+	aID := EVMAssetID{AssetID: UnsafeAssetID(chainID, namespace, reference)}
 	if err := aID.Validate(); err != nil {
 		return EVMAssetID{}, err
 	}
@@ -161,22 +332,19 @@ func NewEVMAssetID(chainID ChainID, namespace, reference string) (EVMAssetID, er
 }
 
 func UnsafeEVMAssetID(chainID ChainID, namespace, reference string) EVMAssetID {
-	aID := EVMAssetID{AssetID: AssetID{chainID, namespace, reference}}
+	aID := EVMAssetID{AssetID: UnsafeAssetID(chainID, namespace, reference)}
 	aID.checksum()
 	return aID
 }
 
 func (a *EVMAssetID) checksum() {
-	split := strings.Split(a.Reference, "/")
 	// Make reference checksummed
-	split[0] = a.Address().Hex()
-	a.Reference = strings.Join(split, "/")
+	a.Reference = a.Address().Hex()
 }
 
 func (a EVMAssetID) Validate() error {
-	split := strings.Split(a.Reference, "/")
-	if ok := common.IsHexAddress(split[0]); !ok {
-		return fmt.Errorf("invalid eth address: %s", split[0])
+	if ok := common.IsHexAddress(a.Reference); !ok {
+		return fmt.Errorf("invalid eth address: %s", a.Reference)
 	}
 
 	if a.ChainID.Namespace != "eip155" {
@@ -186,9 +354,38 @@ func (a EVMAssetID) Validate() error {
 	return a.AssetID.Validate()
 }
 
+func (a *EVMAssetID) Parse(s string) error {
+	return parseTyped(s, a.AssetID.Parse, a)
+}
+
+func (a *EVMAssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *EVMAssetID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *EVMAssetID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AssetID.UnmarshalJSON, a)
+}
+
+func (a *EVMAssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *EVMAssetID) Scan(src interface{}) error {
+	return scanParse(AssetIDKind, src, a.Parse)
+}
+
+func (a *EVMAssetID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
+}
+
 func (a EVMAssetID) Address() common.Address {
-	split := strings.Split(a.Reference, "/")
-	return common.HexToAddress(split[0])
+	return common.HexToAddress(a.Reference)
 }
 
 func (a EVMAssetID) AccountID() EVMAccountID {
@@ -147,8 +147,8 @@ func TestInvalidEVMAssetID(t *testing.T) {
 		err: fmt.Errorf("invalid chain namespace: %s", "cosmos"),
 	}} {
 		a := EVMAssetID{}
-		if err := a.Parse(tc.id); err != nil {
-			t.Errorf("Failed to parse asset id")
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse asset id should error")
 		}
 
 		if a.String() != tc.id {
@@ -174,3 +174,55 @@ func TestInvalidEVMAssetID(t *testing.T) {
 		}
 	}
 }
+
+func TestAssetIDTokenID(t *testing.T) {
+	a := AssetID{}
+	a.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769")
+
+	if a.Reference != "0x06012c8cf97BEaD5deAe237070F9587f8E7A266d" || a.TokenID != "771769" {
+		t.Fatalf("Token id not split from reference: %+v", a)
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Failed to marshal to json")
+	}
+
+	if string(b) != `{"chain_id":{"namespace":"eip155","reference":"1"},"asset_namespace":"erc721","asset_reference":"0x06012c8cf97BEaD5deAe237070F9587f8E7A266d","token_id":"771769"}` {
+		t.Errorf("Marshalled asset id invalid: %s", b)
+	}
+
+	// Token ids stored as part of the reference are still accepted
+	legacy := AssetID{}
+	if err := json.Unmarshal([]byte(`{"chain_id":{"namespace":"eip155","reference":"1"},"asset_namespace":"erc721","asset_reference":"0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769"}`), &legacy); err != nil {
+		t.Fatalf("Failed to unmarshal legacy json: %v", err)
+	}
+
+	if legacy != a {
+		t.Errorf("Unmarshalled legacy asset id invalid: %+v", legacy)
+	}
+
+	nft := ERC721AssetID{}
+	nft.ParseX(a.String())
+	if nft.AssetID != a {
+		t.Errorf("ERC721 asset id conversion invalid: %+v", nft.AssetID)
+	}
+
+	if nft.AssetType().String() != "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d" {
+		t.Errorf("ERC721 asset type conversion invalid: %s", nft.AssetType())
+	}
+
+	// An empty token id is not dropped
+	empty := "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/"
+	if err := new(AssetID).Parse(empty); !errors.Is(err, ErrTokenIDInvalid) {
+		t.Errorf("Parse of empty token id should error, got: %v", err)
+	}
+
+	if _, err := NewAssetID(a.ChainID, a.Namespace, a.Reference+"/"); !errors.Is(err, ErrTokenIDInvalid) {
+		t.Errorf("Create of empty token id should error, got: %v", err)
+	}
+
+	if u := UnsafeAssetID(a.ChainID, a.Namespace, a.Reference+"/"); u.String() != empty {
+		t.Errorf("Unsafe asset id should keep the separator: %s", u)
+	}
+}
@@ -0,0 +1,195 @@
+package caip
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AssetType is a CAIP-19 asset type, an asset id without the token id
+// component.
+type AssetType struct {
+	ChainID   ChainID `json:"chain_id"`
+	Namespace string  `json:"asset_namespace"`
+	Reference string  `json:"asset_reference"`
+}
+
+func NewAssetType(chainID ChainID, namespace, reference string) (AssetType, error) {
+	aType := AssetType{chainID, namespace, reference}
+	if err := aType.Validate(); err != nil {
+		return AssetType{}, err
+	}
+
+	return aType, nil
+}
+
+func UnsafeAssetType(chainID ChainID, namespace, reference string) AssetType {
+	return AssetType{chainID, namespace, reference}
+}
+
+func (a AssetType) Validate() error {
+	return a.AssetID("").validate(AssetTypeKind)
+}
+
+func (a AssetType) AssetID(tokenID string) AssetID {
+	return AssetID{a.ChainID, a.Namespace, a.Reference, tokenID}
+}
+
+func (a AssetType) WithTokenID(tokenID string) (AssetID, error) {
+	aID := a.AssetID(tokenID)
+	if err := aID.Validate(); err != nil {
+		return AssetID{}, err
+	}
+
+	return aID, nil
+}
+
+// String returns the asset type as it is, see ChainID.String.
+func (a AssetType) String() string {
+	return a.ChainID.String() + "/" + a.Namespace + ":" + a.Reference
+}
+
+// Canonical returns the CAIP string form of a valid asset type with its
+// reference normalized.
+func (a AssetType) Canonical() (string, error) {
+	n, err := a.Normalize()
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// Normalize returns the asset type with the reference in the form preferred by
+// its namespace, see AssetID.Normalize.
+func (a AssetType) Normalize() (AssetType, error) {
+	if err := a.Validate(); err != nil {
+		return AssetType{}, err
+	}
+
+	aID, err := a.AssetID("").Normalize()
+	if err != nil {
+		return AssetType{}, err
+	}
+
+	return aID.AssetType(), nil
+}
+
+// Equal reports whether both asset types refer to the same asset. Invalid
+// asset types are compared as they are.
+func (a AssetType) Equal(other AssetType) bool {
+	return a.AssetID("").Equal(other.AssetID(""))
+}
+
+// Compare orders normalized asset types by chain id, namespace and then
+// reference, returning -1, 0 or 1. It is consistent with Equal.
+func (a AssetType) Compare(other AssetType) int {
+	return a.AssetID("").Compare(other.AssetID(""))
+}
+
+func (a AssetType) compare(other AssetType) int {
+	if n := a.ChainID.Compare(other.ChainID); n != 0 {
+		return n
+	}
+	if n := strings.Compare(a.Namespace, other.Namespace); n != 0 {
+		return n
+	}
+	return strings.Compare(a.Reference, other.Reference)
+}
+
+// Format implements fmt.Formatter. %+v prints the components of the asset type.
+func (a AssetType) Format(f fmt.State, verb rune) {
+	type assetTypeFormat AssetType
+	format(f, verb, "AssetType", a.String(), assetTypeFormat(a))
+}
+
+func (a *AssetType) Parse(s string) error {
+	g, chainID, namespace, refStart, ok := scanAssetType(s)
+	if !ok {
+		return malformedError(AssetTypeKind, s)
+	}
+	g = g.set(assetReferenceOK, validAssetReference(s[refStart:]))
+	g = g.set(tokenIDOK, true)
+
+	*a = AssetType{chainID, namespace, s[refStart:]}
+	if err := a.AssetID("").validateGrammar(AssetTypeKind, g); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *AssetType) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *AssetType) UnmarshalJSON(data []byte) error {
+	if ok, err := unmarshalJSONString(data, a.Parse); ok {
+		return err
+	}
+
+	type AssetTypeAlias AssetType
+	aa := (*AssetTypeAlias)(a)
+	if err := json.Unmarshal(data, &aa); err != nil {
+		return err
+	}
+
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a AssetType) MarshalJSON() ([]byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	type AssetTypeAlias AssetType
+	ca := (AssetTypeAlias)(a)
+	return json.Marshal(ca)
+}
+
+// MarshalText does not normalize, see AccountID.MarshalText.
+func (a AssetType) MarshalText() ([]byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(a.String()), nil
+}
+
+func (a *AssetType) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a AssetType) Value() (driver.Value, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a.String(), nil
+}
+
+func (a *AssetType) Scan(src interface{}) error {
+	s, err := scanString(AssetTypeKind, src)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Parse(s); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a AssetType) MarshalGQL(w io.Writer) {
+	marshalGQL(w, a.String())
+}
+
+func (a *AssetType) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetTypeKind, v, a.Parse)
+}
@@ -0,0 +1,86 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAssetType(t *testing.T) {
+	for _, tc := range []struct {
+		id string
+	}{{
+		// Ether Token
+		id: "eip155:1/slip44:60",
+	}, {
+		// DAI Token
+		id: "eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f",
+	}, {
+		// CryptoKitties Collectible
+		id: "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d",
+	}, {
+		// Hedera HBAR-backed token
+		id: "hedera:mainnet/token:0.0.55492",
+	}} {
+		a := AssetType{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Fatalf("Failed to parse asset type: %v", err)
+		}
+
+		if a.String() != tc.id {
+			t.Fatalf("Failed to serialize asset type to string")
+		}
+
+		if _, err := NewAssetType(a.ChainID, a.Namespace, a.Reference); err != nil {
+			t.Fatalf("Failed to create asset type from namespace and reference")
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Fatalf("Failed to marshal to json")
+		}
+
+		a = AssetType{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Fatalf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Fatalf("Unmarshalled asset type invalid")
+		}
+
+		a2 := AssetType{}
+		if err := a2.Scan(a.String()); err != nil {
+			t.Errorf("Scanning value from sql.NullString")
+		}
+
+		if a2.String() != a.String() {
+			t.Errorf("Scanned value not valid")
+		}
+	}
+}
+
+func TestAssetTypeTokenID(t *testing.T) {
+	a := AssetType{}
+	if err := a.Parse("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769"); !errors.Is(err, ErrReferenceInvalid) {
+		t.Errorf("Asset type with token id should not parse, got: %v", err)
+	}
+
+	a.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d")
+	aID, err := a.WithTokenID("771769")
+	if err != nil {
+		t.Fatalf("Failed to create asset id from asset type: %v", err)
+	}
+
+	if aID.String() != "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769" {
+		t.Errorf("Asset id from asset type invalid: %s", aID)
+	}
+
+	if aID.AssetType() != a {
+		t.Errorf("Asset type from asset id invalid")
+	}
+
+	if _, err := a.WithTokenID("cat"); !errors.Is(err, ErrTokenIDInvalid) {
+		t.Errorf("expected token id error, got: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package caip
+
+import (
+	"errors"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	errBase58Invalid = errors.New("invalid base58 string")
+
+	base58Index = func() [256]int {
+		var index [256]int
+		for i := range index {
+			index[i] = -1
+		}
+		for i := 0; i < len(base58Alphabet); i++ {
+			index[base58Alphabet[i]] = i
+		}
+		return index
+	}()
+)
+
+func base58Encode(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	// log(256) / log(58) rounded up
+	buf := make([]byte, (len(b)-zeros)*138/100+1)
+	size := 0
+	for _, v := range b[zeros:] {
+		carry := int(v)
+		i := 0
+		for j := len(buf) - 1; (carry != 0 || i < size) && j >= 0; j-- {
+			carry += 256 * int(buf[j])
+			buf[j] = byte(carry % 58)
+			carry /= 58
+			i++
+		}
+		size = i
+	}
+
+	out := make([]byte, 0, zeros+size)
+	for i := 0; i < zeros; i++ {
+		out = append(out, base58Alphabet[0])
+	}
+	for _, v := range buf[len(buf)-size:] {
+		out = append(out, base58Alphabet[v])
+	}
+	return string(out)
+}
+
+func base58Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// log(58) / log(256) rounded up
+	buf := make([]byte, (len(s)-zeros)*733/1000+1)
+	size := 0
+	for i := zeros; i < len(s); i++ {
+		carry := base58Index[s[i]]
+		if carry < 0 {
+			return nil, errBase58Invalid
+		}
+
+		k := 0
+		for j := len(buf) - 1; (carry != 0 || k < size) && j >= 0; j-- {
+			carry += 58 * int(buf[j])
+			buf[j] = byte(carry % 256)
+			carry /= 256
+			k++
+		}
+		size = k
+	}
+
+	out := make([]byte, zeros+size)
+	copy(out[zeros:], buf[len(buf)-size:])
+	return out, nil
+}
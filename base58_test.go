@@ -0,0 +1,52 @@
+package caip
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	for _, tc := range []struct {
+		hex string
+		b58 string
+	}{{
+		hex: "",
+		b58: "",
+	}, {
+		hex: "61",
+		b58: "2g",
+	}, {
+		hex: "626262",
+		b58: "a3gV",
+	}, {
+		hex: "00000000000000000000",
+		b58: "1111111111",
+	}, {
+		hex: "00eb15231dfceb60925886b67d065299925915aeb172c06647",
+		b58: "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L",
+	}, {
+		hex: "516b6fcd0f",
+		b58: "ABnLTmg",
+	}} {
+		b, _ := hex.DecodeString(tc.hex)
+		if s := base58Encode(b); s != tc.b58 {
+			t.Errorf("%s: base58 encoding invalid: %s", tc.hex, s)
+		}
+
+		d, err := base58Decode(tc.b58)
+		if err != nil {
+			t.Errorf("%s: failed to decode base58: %v", tc.b58, err)
+		}
+
+		if !bytes.Equal(d, b) {
+			t.Errorf("%s: base58 decoding invalid: %x", tc.b58, d)
+		}
+	}
+
+	for _, s := range []string{"0", "O", "I", "l", "abc!"} {
+		if _, err := base58Decode(s); err == nil {
+			t.Errorf("%s: base58 decoding should error", s)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package caip
+
+import (
+	"errors"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+type bech32Variant int
+
+const (
+	bech32  bech32Variant = 1
+	bech32m bech32Variant = 0x2bc830a3
+)
+
+var (
+	errBech32Invalid  = errors.New("invalid bech32 string")
+	errBech32Checksum = errors.New("invalid bech32 checksum")
+)
+
+func bech32Polymod(values []byte) int {
+	gen := []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := 1
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ int(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// bech32Decode returns the human readable part and the 5-bit data words
+// without the checksum.
+func bech32Decode(s string) (string, []byte, bech32Variant, error) {
+	if len(s) < 8 || len(s) > 90 {
+		return "", nil, 0, errBech32Invalid
+	}
+
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errBech32Invalid
+	}
+	s = strings.ToLower(s)
+
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, errBech32Invalid
+	}
+
+	hrp := s[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, errBech32Invalid
+		}
+	}
+
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Charset, s[i])
+		if v < 0 {
+			return "", nil, 0, errBech32Invalid
+		}
+		data = append(data, byte(v))
+	}
+
+	variant := bech32Variant(bech32Polymod(append(bech32HRPExpand(hrp), data...)))
+	if variant != bech32 && variant != bech32m {
+		return "", nil, 0, errBech32Checksum
+	}
+
+	return hrp, data[:len(data)-6], variant, nil
+}
+
+func bech32Encode(hrp string, data []byte, variant bech32Variant) string {
+	values := append(bech32HRPExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ int(variant)
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range data {
+		b.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return b.String()
+}
+
+// bech32ConvertBits regroups data from fromBits to toBits wide words.
+func bech32ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc, bits := 0, uint(0)
+	maxv := 1<<toBits - 1
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if int(v)>>fromBits != 0 {
+			return nil, errBech32Invalid
+		}
+		acc = acc<<fromBits | int(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errBech32Invalid
+	}
+
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package caip
+
+import (
+	"strings"
+	"testing"
+)
+
+// See: https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki#test-vectors
+func TestBech32(t *testing.T) {
+	for _, tc := range []struct {
+		s       string
+		variant bech32Variant
+	}{{
+		s:       "A12UEL5L",
+		variant: bech32,
+	}, {
+		s:       "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		variant: bech32,
+	}, {
+		s:       "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		variant: bech32,
+	}, {
+		s:       "A1LQFN3A",
+		variant: bech32m,
+	}, {
+		s:       "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		variant: bech32m,
+	}, {
+		s:       "split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		variant: bech32m,
+	}} {
+		hrp, data, variant, err := bech32Decode(tc.s)
+		if err != nil {
+			t.Errorf("%s: failed to decode bech32: %v", tc.s, err)
+			continue
+		}
+
+		if variant != tc.variant {
+			t.Errorf("%s: unexpected variant: %x", tc.s, variant)
+		}
+
+		if s := bech32Encode(hrp, data, variant); s != strings.ToLower(tc.s) {
+			t.Errorf("%s: bech32 encoding invalid: %s", tc.s, s)
+		}
+	}
+
+	for _, s := range []string{
+		"pzry9x0s0muk",
+		"1pzry9x0s0muk",
+		"x1b4n0q5v",
+		"li1dgmt3",
+		"A1G7SGD8",
+		"abcdef1Qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx",
+	} {
+		if _, _, _, err := bech32Decode(s); err == nil {
+			t.Errorf("%s: bech32 decoding should error", s)
+		}
+	}
+}
+
+func TestBech32ConvertBits(t *testing.T) {
+	in := []byte{0xff, 0x00, 0xab}
+	words, err := bech32ConvertBits(in, 8, 5, true)
+	if err != nil {
+		t.Fatalf("Failed to convert bits: %v", err)
+	}
+
+	out, err := bech32ConvertBits(words, 5, 8, false)
+	if err != nil {
+		t.Fatalf("Failed to convert bits: %v", err)
+	}
+
+	if string(out) != string(in) {
+		t.Errorf("Converted bits invalid: %x", out)
+	}
+}
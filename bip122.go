@@ -0,0 +1,228 @@
+package caip
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type BIP122AddressType string
+
+const (
+	BIP122P2PKH   BIP122AddressType = "p2pkh"
+	BIP122P2SH    BIP122AddressType = "p2sh"
+	BIP122P2WPKH  BIP122AddressType = "p2wpkh"
+	BIP122P2WSH   BIP122AddressType = "p2wsh"
+	BIP122P2TR    BIP122AddressType = "p2tr"
+	BIP122Witness BIP122AddressType = "witness"
+	// BIP122Unknown is reported for base58check addresses on chains without a
+	// registered network, where the version byte cannot be interpreted.
+	BIP122Unknown BIP122AddressType = "unknown"
+)
+
+// BIP122Network holds the address parameters of a chain, identified by its
+// truncated genesis block hash.
+type BIP122Network struct {
+	Name               string
+	PubKeyHashVersion  byte
+	ScriptHashVersions []byte
+	// Bech32HRP is empty for chains without segwit addresses
+	Bech32HRP string
+}
+
+var (
+	bip122ReferenceRegex = regexp.MustCompile("^[0-9a-f]{32}$")
+	bip122NetworksMu     sync.RWMutex
+	bip122Networks       = map[string]BIP122Network{
+		"000000000019d6689c085ae165831e93": {"Bitcoin", 0x00, []byte{0x05}, "bc"},
+		"000000000933ea01ad0ee984209779ba": {"Bitcoin Testnet", 0x6f, []byte{0xc4}, "tb"},
+		"00000000da84f2bafbbc53dee25a72ae": {"Bitcoin Testnet4", 0x6f, []byte{0xc4}, "tb"},
+		"00000008819873e925422c1ff0f99f7c": {"Bitcoin Signet", 0x6f, []byte{0xc4}, "tb"},
+		"0f9188f13cb7b2c71f2a335e3a4fc328": {"Bitcoin Regtest", 0x6f, []byte{0xc4}, "bcrt"},
+		"12a765e31ffd4059bada1e25190f6e98": {"Litecoin", 0x30, []byte{0x32, 0x05}, "ltc"},
+		"4966625a4b2851d9fdee139e56211a0d": {"Litecoin Testnet", 0x6f, []byte{0x3a, 0xc4}, "tltc"},
+		"1a91e3dace36e2be3bf030a65679fe82": {"Dogecoin", 0x1e, []byte{0x16}, ""},
+	}
+)
+
+type bip122Namespace struct{}
+
+func init() {
+	RegisterNamespace("bip122", bip122Namespace{})
+}
+
+func RegisterBIP122Network(reference string, network BIP122Network) {
+	bip122NetworksMu.Lock()
+	defer bip122NetworksMu.Unlock()
+	bip122Networks[reference] = network
+}
+
+func LookupBIP122Network(reference string) (BIP122Network, bool) {
+	bip122NetworksMu.RLock()
+	defer bip122NetworksMu.RUnlock()
+	network, ok := bip122Networks[reference]
+	return network, ok
+}
+
+func (bip122Namespace) ValidateReference(reference string) error {
+	if ok := bip122ReferenceRegex.MatchString(reference); !ok {
+		return fmt.Errorf("%w: invalid genesis block hash: %s", ErrReferenceInvalid, reference)
+	}
+
+	return nil
+}
+
+func (bip122Namespace) ValidateAddress(chainID ChainID, address string) error {
+	if _, err := bip122AddressType(chainID, address); err != nil {
+		return fmt.Errorf("%w: %s", ErrAddressInvalid, err)
+	}
+
+	return nil
+}
+
+func (n bip122Namespace) NormalizeAddress(chainID ChainID, address string) (string, error) {
+	typ, err := bip122AddressType(chainID, address)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrAddressInvalid, err)
+	}
+
+	switch typ {
+	case BIP122P2WPKH, BIP122P2WSH, BIP122P2TR, BIP122Witness:
+		return strings.ToLower(address), nil
+	}
+
+	return address, nil
+}
+
+func (bip122Namespace) ValidateAsset(a AssetID) error {
+	return nil
+}
+
+func bip122AddressType(chainID ChainID, address string) (BIP122AddressType, error) {
+	network, known := LookupBIP122Network(chainID.Reference)
+
+	if b, err := base58Decode(address); err == nil && len(b) == 25 {
+		sum := doubleSHA256(b[:21])
+		if !bytes.Equal(sum[:4], b[21:]) {
+			return "", fmt.Errorf("invalid bitcoin address checksum: %s", address)
+		}
+
+		if !known {
+			return BIP122Unknown, nil
+		}
+
+		if b[0] == network.PubKeyHashVersion {
+			return BIP122P2PKH, nil
+		}
+
+		if bytes.IndexByte(network.ScriptHashVersions, b[0]) >= 0 {
+			return BIP122P2SH, nil
+		}
+
+		return "", fmt.Errorf("bitcoin address version %#02x does not match %s", b[0], network.Name)
+	}
+
+	hrp, data, variant, err := bech32Decode(address)
+	if err != nil || len(data) < 1 {
+		return "", fmt.Errorf("invalid bitcoin address: %s", address)
+	}
+
+	if known && hrp != network.Bech32HRP {
+		return "", fmt.Errorf("bitcoin address prefix %s does not match %s", hrp, network.Name)
+	}
+
+	version := data[0]
+	program, err := bech32ConvertBits(data[1:], 5, 8, false)
+	if err != nil || version > 16 || len(program) < 2 || len(program) > 40 {
+		return "", fmt.Errorf("invalid segwit address: %s", address)
+	}
+
+	if (version == 0) != (variant == bech32) {
+		return "", fmt.Errorf("invalid segwit address checksum: %s", address)
+	}
+
+	switch {
+	case version == 0 && len(program) == 20:
+		return BIP122P2WPKH, nil
+	case version == 0 && len(program) == 32:
+		return BIP122P2WSH, nil
+	case version == 0:
+		return "", fmt.Errorf("invalid segwit address program length: %s", address)
+	case version == 1 && len(program) == 32:
+		return BIP122P2TR, nil
+	}
+
+	return BIP122Witness, nil
+}
+
+func doubleSHA256(b []byte) [32]byte {
+	sum := sha256.Sum256(b)
+	return sha256.Sum256(sum[:])
+}
+
+type BIP122AccountID struct {
+	AccountID
+}
+
+func NewBIP122AccountID(chainID ChainID, address string) (BIP122AccountID, error) {
+	aID := BIP122AccountID{AccountID{chainID, address}}
+	if err := aID.Validate(); err != nil {
+		return BIP122AccountID{}, err
+	}
+
+	return aID, nil
+}
+
+func UnsafeBIP122AccountID(chainID ChainID, address string) BIP122AccountID {
+	return BIP122AccountID{AccountID{chainID, address}}
+}
+
+func (a BIP122AccountID) Validate() error {
+	if _, err := bip122AddressType(a.ChainID, a.Address); err != nil {
+		return err
+	}
+
+	if a.ChainID.Namespace != "bip122" {
+		return fmt.Errorf("invalid chain namespace: %s", a.ChainID.Namespace)
+	}
+
+	return a.AccountID.Validate()
+}
+
+func (a *BIP122AccountID) Parse(s string) error {
+	return parseTyped(s, a.AccountID.Parse, a)
+}
+
+func (a *BIP122AccountID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *BIP122AccountID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *BIP122AccountID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AccountID.UnmarshalJSON, a)
+}
+
+func (a *BIP122AccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *BIP122AccountID) Scan(src interface{}) error {
+	return scanParse(AccountIDKind, src, a.Parse)
+}
+
+func (a *BIP122AccountID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AccountIDKind, v, a.Parse)
+}
+
+func (a BIP122AccountID) AddressType() BIP122AddressType {
+	typ, _ := bip122AddressType(a.ChainID, a.Address)
+	return typ
+}
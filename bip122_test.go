@@ -0,0 +1,159 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBIP122AccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		typ BIP122AddressType
+	}{{
+		// Bitcoin mainnet P2PKH
+		id:  "bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6",
+		typ: BIP122P2PKH,
+	}, {
+		// Bitcoin mainnet P2SH
+		id:  "bip122:000000000019d6689c085ae165831e93:3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy",
+		typ: BIP122P2SH,
+	}, {
+		// Bitcoin mainnet P2WPKH
+		id:  "bip122:000000000019d6689c085ae165831e93:bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		typ: BIP122P2WPKH,
+	}, {
+		// Bitcoin mainnet P2WSH
+		id:  "bip122:000000000019d6689c085ae165831e93:bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3",
+		typ: BIP122P2WSH,
+	}, {
+		// Bitcoin mainnet P2TR
+		id:  "bip122:000000000019d6689c085ae165831e93:bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+		typ: BIP122P2TR,
+	}, {
+		// Bitcoin testnet P2PKH
+		id:  "bip122:000000000933ea01ad0ee984209779ba:mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn",
+		typ: BIP122P2PKH,
+	}, {
+		// Bitcoin testnet P2WPKH
+		id:  "bip122:000000000933ea01ad0ee984209779ba:tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+		typ: BIP122P2WPKH,
+	}, {
+		// Litecoin P2PKH
+		id:  "bip122:12a765e31ffd4059bada1e25190f6e98:LUEweDxDA4WhvWiNXXSxjM9CYzHPJv4QQF",
+		typ: BIP122P2PKH,
+	}, {
+		// Litecoin P2SH
+		id:  "bip122:12a765e31ffd4059bada1e25190f6e98:MGv9cSYnaRSTZNzYaN7bhbgmozoGkKBvCn",
+		typ: BIP122P2SH,
+	}, {
+		// Litecoin P2WPKH
+		id:  "bip122:12a765e31ffd4059bada1e25190f6e98:ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9",
+		typ: BIP122P2WPKH,
+	}, {
+		// Dogecoin P2PKH
+		id:  "bip122:1a91e3dace36e2be3bf030a65679fe82:DEA5vGb2NpAwCiCp5yTE16F3DueQUVivQp",
+		typ: BIP122P2PKH,
+	}, {
+		// Feathercoin, no registered network
+		id:  "bip122:fdbe99b90c90bae7505796461471d89a:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+		typ: BIP122Unknown,
+	}} {
+		a := BIP122AccountID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse account id: %v", err)
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		if _, err := NewBIP122AccountID(a.ChainID, a.Address); err != nil {
+			t.Errorf("Failed to create account id from address")
+		}
+
+		if a.AddressType() != tc.typ {
+			t.Errorf("%s: address type invalid: %s", tc.id, a.AddressType())
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = BIP122AccountID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled account id invalid")
+		}
+	}
+}
+
+func TestInvalidBIP122AccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		err error
+	}{{
+		// Bad checksum
+		id:  "bip122:000000000019d6689c085ae165831e93:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb",
+		err: fmt.Errorf("invalid bitcoin address checksum: %s", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb"),
+	}, {
+		// Testnet address on mainnet
+		id:  "bip122:000000000019d6689c085ae165831e93:mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn",
+		err: fmt.Errorf("bitcoin address version 0x6f does not match Bitcoin"),
+	}, {
+		// Testnet segwit address on mainnet
+		id:  "bip122:000000000019d6689c085ae165831e93:tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+		err: fmt.Errorf("bitcoin address prefix tb does not match Bitcoin"),
+	}, {
+		// Witness version 0 with a bech32m checksum
+		id:  "bip122:000000000019d6689c085ae165831e93:bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",
+		err: fmt.Errorf("invalid segwit address checksum: %s", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh"),
+	}, {
+		// Junk
+		id:  "bip122:000000000019d6689c085ae165831e93:notanaddress",
+		err: fmt.Errorf("invalid bitcoin address: %s", "notanaddress"),
+	}, {
+		id:  "cosmos:cosmoshub-3:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+		err: fmt.Errorf("invalid chain namespace: %s", "cosmos"),
+	}} {
+		a := BIP122AccountID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse account id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		_, err := NewBIP122AccountID(a.ChainID, a.Address)
+		if err == nil {
+			t.Fatalf("Create account id should error")
+		}
+
+		if err.Error() != tc.err.Error() {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
+
+func TestBIP122Namespace(t *testing.T) {
+	if err := new(ChainID).Parse("bip122:000000000019D6689C085AE165831E93"); !errors.Is(err, ErrReferenceInvalid) {
+		t.Errorf("expected reference error, got: %v", err)
+	}
+
+	a := AccountID{}
+	a.ParseX("bip122:000000000019d6689c085ae165831e93:BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4")
+	n, err := a.Normalize()
+	if err != nil {
+		t.Fatalf("Failed to normalize account id: %v", err)
+	}
+
+	if n.Address != "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4" {
+		t.Errorf("Normalized address invalid: %s", n.Address)
+	}
+}
@@ -0,0 +1,337 @@
+// Package bulk parses and validates CAIP identifiers from NDJSON and CSV
+// streams.
+//
+// Identifiers are delivered on a channel in input order, normalized with
+// Normalize. Invalid identifiers are reported with their line number without
+// stopping the stream:
+//
+//	for res := range bulk.AccountIDs(ctx, f, bulk.Options{Format: bulk.CSV, Column: "account"}) {
+//		if res.Err != nil {
+//			log.Println(res.Err) // line 42: invalid account id ...
+//			continue
+//		}
+//		accounts = append(accounts, res.AccountID)
+//	}
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	caip "github.com/ChainAgnostic/go-caip"
+)
+
+type Format int
+
+const (
+	// NDJSON reads one JSON value per line. Blank lines are skipped.
+	NDJSON Format = iota
+	// CSV reads comma-separated values with a header row.
+	CSV
+)
+
+type Options struct {
+	Format Format
+	// Column is the CSV column or NDJSON object key holding the identifier. It
+	// is required for CSV. Without it NDJSON lines are identifiers in their
+	// JSON form, either a string or an object.
+	Column string
+	// Workers is the number of goroutines parsing identifiers, 1 if not set.
+	// Results are delivered in input order regardless.
+	Workers int
+}
+
+// LineError is an error reading or parsing the identifier on a line. For CSV,
+// Line is the number of the record, counting the header as 1, which is the
+// line number unless quoted fields span lines.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+type AccountIDResult struct {
+	Line      int
+	AccountID caip.AccountID
+	Err       error
+}
+
+// AccountIDs parses account ids from r until it is exhausted, a read error
+// occurs or ctx is done, then closes the returned channel. Read errors are
+// delivered as a final result.
+func AccountIDs(ctx context.Context, r io.Reader, opts Options) <-chan AccountIDResult {
+	out := make(chan AccountIDResult)
+	results := stream(ctx, r, opts, func(f field) (interface{}, error) {
+		var aID caip.AccountID
+		if err := f.decode(&aID); err != nil {
+			return nil, err
+		}
+		return aID.Normalize()
+	})
+
+	go func() {
+		defer close(out)
+		for res := range results {
+			aID, _ := res.value.(caip.AccountID)
+			select {
+			case out <- AccountIDResult{res.line, aID, res.err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+type AssetIDResult struct {
+	Line    int
+	AssetID caip.AssetID
+	Err     error
+}
+
+// AssetIDs parses asset ids from r, see AccountIDs.
+func AssetIDs(ctx context.Context, r io.Reader, opts Options) <-chan AssetIDResult {
+	out := make(chan AssetIDResult)
+	results := stream(ctx, r, opts, func(f field) (interface{}, error) {
+		var aID caip.AssetID
+		if err := f.decode(&aID); err != nil {
+			return nil, err
+		}
+		return aID.Normalize()
+	})
+
+	go func() {
+		defer close(out)
+		for res := range results {
+			aID, _ := res.value.(caip.AssetID)
+			select {
+			case out <- AssetIDResult{res.line, aID, res.err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// field is the identifier read from a line, in its string form for CSV and
+// its JSON form for NDJSON, or the error extracting it from the line.
+type field struct {
+	line int
+	data []byte
+	json bool
+	err  error
+}
+
+type identifier interface {
+	Parse(s string) error
+	json.Unmarshaler
+}
+
+func (f field) decode(id identifier) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.json {
+		return json.Unmarshal(f.data, id)
+	}
+	return id.Parse(string(f.data))
+}
+
+type result struct {
+	line  int
+	value interface{}
+	err   error
+}
+
+type job struct {
+	field  field
+	result chan result
+}
+
+// stream reads fields from r and parses them with parse on opts.Workers
+// goroutines, delivering the results in input order.
+func stream(ctx context.Context, r io.Reader, opts Options, parse func(field) (interface{}, error)) <-chan result {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan job, workers)
+	// pending holds the jobs in input order for the output goroutine
+	pending := make(chan job, workers)
+	out := make(chan result)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				value, err := parse(j.field)
+				if err != nil {
+					err = &LineError{j.field.line, err}
+				}
+				j.result <- result{j.field.line, value, err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+
+		err := read(r, opts, func(f field) bool {
+			j := job{f, make(chan result, 1)}
+			return enqueue(ctx, pending, j) && enqueue(ctx, jobs, j)
+		})
+
+		if err != nil {
+			// Read errors are resolved already, so they skip the workers
+			j := job{result: make(chan result, 1)}
+			j.result <- result{line: err.Line, err: err}
+			enqueue(ctx, pending, j)
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for j := range pending {
+			var res result
+			select {
+			case res = <-j.result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// enqueue sends j on jobs and reports whether ctx is still active.
+func enqueue(ctx context.Context, jobs chan<- job, j job) bool {
+	select {
+	case jobs <- j:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// read calls fn with each identifier in r until fn returns false.
+func read(r io.Reader, opts Options, fn func(field) bool) *LineError {
+	switch opts.Format {
+	case NDJSON:
+		return readNDJSON(r, opts.Column, fn)
+	case CSV:
+		return readCSV(r, opts.Column, fn)
+	default:
+		return &LineError{0, fmt.Errorf("unknown format: %d", opts.Format)}
+	}
+}
+
+// maxLine is the longest NDJSON line read.
+const maxLine = 1 << 20
+
+func readNDJSON(r io.Reader, column string, fn func(field) bool) *LineError {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		// The scanner reuses its buffer
+		f := field{line: line, data: append([]byte(nil), data...), json: true}
+		if column != "" {
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(data, &object); err != nil {
+				f.err = err
+			} else if value, ok := object[column]; ok {
+				f.data = value
+			} else {
+				f.err = fmt.Errorf("missing field: %q", column)
+			}
+		}
+
+		if !fn(f) {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return &LineError{line + 1, err}
+	}
+	return nil
+}
+
+func readCSV(r io.Reader, column string, fn func(field) bool) *LineError {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	// Short and long rows are reported per line rather than ending the read
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return &LineError{1, fmt.Errorf("reading header: %w", err)}
+	}
+
+	index := -1
+	for i, name := range header {
+		if name == column {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return &LineError{1, fmt.Errorf("missing column: %q", column)}
+	}
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		f := field{line: line}
+		var perr *csv.ParseError
+		switch {
+		case errors.As(err, &perr):
+			// The reader skips past malformed records
+			f.err = err
+		case err != nil:
+			return &LineError{line, err}
+		case index >= len(record):
+			f.err = fmt.Errorf("missing column: %q", column)
+		default:
+			f.data = []byte(record[index])
+		}
+
+		if !fn(f) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	caip "github.com/ChainAgnostic/go-caip"
+)
+
+func TestAccountIDsCSV(t *testing.T) {
+	data := `name,account
+alice,eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb
+bob,eip155:1
+carol,"cosmos:cosmoshub-3:COSMOS1T2UFLQWQE0FSJ0SHCFKRVPUKEWCW40YJJ6HDC0"
+`
+
+	var results []AccountIDResult
+	for res := range AccountIDs(context.Background(), strings.NewReader(data), Options{Format: CSV, Column: "account"}) {
+		results = append(results, res)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got: %d", len(results))
+	}
+
+	if results[0].Err != nil || results[0].Line != 2 || results[0].AccountID.String() != "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb" {
+		t.Errorf("Result invalid: %+v", results[0])
+	}
+
+	var lerr *LineError
+	if !errors.As(results[1].Err, &lerr) || lerr.Line != 3 || !errors.Is(results[1].Err, caip.ErrMalformed) {
+		t.Errorf("expected malformed error on line 3, got: %v", results[1].Err)
+	}
+
+	if results[2].Err != nil || results[2].AccountID.String() != "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0" {
+		t.Errorf("Result invalid: %+v", results[2])
+	}
+}
+
+func TestCSVBadRows(t *testing.T) {
+	data := `name,account
+alice,eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb
+bob,eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb,extra
+carol
+dave,"eip155:1:0x"x
+erin,eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb
+`
+
+	var results []AccountIDResult
+	for res := range AccountIDs(context.Background(), strings.NewReader(data), Options{Format: CSV, Column: "account"}) {
+		results = append(results, res)
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got: %d", len(results))
+	}
+
+	for i, tc := range []struct {
+		line int
+		err  string
+	}{
+		{2, ""},
+		// Extra fields are ignored
+		{3, ""},
+		{4, "line 4: missing column: \"account\""},
+		{5, "line 5: "},
+		{6, ""},
+	} {
+		res := results[i]
+		if res.Line != tc.line {
+			t.Errorf("expected line %d, got: %d", tc.line, res.Line)
+		}
+
+		if tc.err == "" && res.Err != nil {
+			t.Errorf("line %d: unexpected error: %v", tc.line, res.Err)
+		}
+
+		if tc.err != "" && (res.Err == nil || !strings.HasPrefix(res.Err.Error(), tc.err)) {
+			t.Errorf("line %d: expected error: %s, got: %v", tc.line, tc.err, res.Err)
+		}
+	}
+}
+
+func TestAssetIDsNDJSON(t *testing.T) {
+	data := `{"asset": "eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f"}
+
+{"asset": {"chain_id": {"namespace": "eip155", "reference": "1"}, "asset_namespace": "slip44", "asset_reference": "60"}}
+{"other": "eip155:1/slip44:60"}
+not json
+{"asset": "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/cat"}
+`
+
+	var results []AssetIDResult
+	for res := range AssetIDs(context.Background(), strings.NewReader(data), Options{Format: NDJSON, Column: "asset"}) {
+		results = append(results, res)
+	}
+
+	expected := []struct {
+		line int
+		id   string
+		err  error
+	}{
+		{1, "eip155:1/erc20:0x6B175474E89094C44Da98b954EedeAC495271d0F", nil},
+		{3, "eip155:1/slip44:60", nil},
+		{4, "", fmt.Errorf("line 4: missing field: \"asset\"")},
+		{5, "", fmt.Errorf("line 5: invalid character 'o' in literal null (expecting 'u')")},
+		{6, "", caip.ErrTokenIDInvalid},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got: %d", len(expected), len(results))
+	}
+
+	for i, e := range expected {
+		res := results[i]
+		if res.Line != e.line {
+			t.Errorf("expected line %d, got: %d", e.line, res.Line)
+		}
+
+		switch {
+		case e.err == nil && res.Err != nil:
+			t.Errorf("line %d: unexpected error: %v", e.line, res.Err)
+		case e.err == nil && res.AssetID.String() != e.id:
+			t.Errorf("line %d: expected %s, got: %s", e.line, e.id, res.AssetID)
+		case e.err != nil && res.Err == nil:
+			t.Errorf("line %d: expected error: %v", e.line, e.err)
+		case e.err != nil && !errors.Is(res.Err, e.err) && res.Err.Error() != e.err.Error():
+			t.Errorf("line %d: expected error: %v, got: %v", e.line, e.err, res.Err)
+		}
+	}
+}
+
+func TestNDJSONStrings(t *testing.T) {
+	data := "\"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb\"\n{\"chain_id\":{\"namespace\":\"eip155\",\"reference\":\"137\"},\"account_address\":\"0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb\"}\n"
+
+	n := 0
+	for res := range AccountIDs(context.Background(), strings.NewReader(data), Options{}) {
+		if res.Err != nil {
+			t.Errorf("Unexpected error: %v", res.Err)
+		}
+		n++
+	}
+
+	if n != 2 {
+		t.Errorf("expected 2 results, got: %d", n)
+	}
+}
+
+func TestWorkersKeepOrder(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("id,account\n")
+	for i := 0; i < 1000; i++ {
+		if i%7 == 0 {
+			fmt.Fprintf(&b, "%d,invalid\n", i)
+			continue
+		}
+		fmt.Fprintf(&b, "%d,eip155:%d:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb\n", i, i+1)
+	}
+
+	line := 2
+	for res := range AccountIDs(context.Background(), strings.NewReader(b.String()), Options{Format: CSV, Column: "account", Workers: 8}) {
+		if res.Line != line {
+			t.Fatalf("expected line %d, got: %d", line, res.Line)
+		}
+
+		i := line - 2
+		if (i%7 == 0) != (res.Err != nil) {
+			t.Errorf("line %d: unexpected result: %+v", line, res)
+		}
+
+		if res.Err == nil && res.AccountID.ChainID.Reference != fmt.Sprint(i+1) {
+			t.Errorf("line %d: result out of order: %s", line, res.AccountID)
+		}
+		line++
+	}
+
+	if line != 1002 {
+		t.Errorf("expected 1000 results, got: %d", line-2)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		opts Options
+		err  string
+	}{{
+		data: "name,address\nalice,eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb\n",
+		opts: Options{Format: CSV, Column: "account"},
+		err:  "line 1: missing column: \"account\"",
+	}, {
+		data: "",
+		opts: Options{Format: CSV, Column: "account"},
+		err:  "line 1: reading header: EOF",
+	}, {
+		data: "account\n\"eip155:1\n",
+		opts: Options{Format: CSV, Column: "account"},
+		// The csv.ParseError message differs between Go versions
+		err: "line 2: ",
+	}, {
+		data: "\"eip155:1:" + strings.Repeat("a", maxLine) + "\"\n",
+		opts: Options{},
+		err:  "line 1: bufio.Scanner: token too long",
+	}} {
+		var results []AccountIDResult
+		for res := range AccountIDs(context.Background(), strings.NewReader(tc.data), tc.opts) {
+			results = append(results, res)
+		}
+
+		if len(results) == 0 || results[len(results)-1].Err == nil {
+			t.Errorf("expected error: %s", tc.err)
+			continue
+		}
+
+		if err := results[len(results)-1].Err; !strings.HasPrefix(err.Error(), tc.err) {
+			t.Errorf("expected error: %s, got: %v", tc.err, err)
+		}
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	data := strings.Repeat("\"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb\"\n", 1000)
+	results := AccountIDs(ctx, strings.NewReader(data), Options{Workers: 4})
+	<-results
+	cancel()
+
+	n := 0
+	for range results {
+		n++
+	}
+
+	if n >= 999 {
+		t.Errorf("stream should stop after cancellation, got %d results", n)
+	}
+}
@@ -0,0 +1,441 @@
+// Package cacao implements CAIP-74 Chain Agnostic CApability Objects, the
+// IPLD representation of signed CAIP-122 sign-in messages.
+package cacao
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/ChainAgnostic/go-caip/siwx"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// HeaderEIP4361 is the header type of CACAOs of eip155 messages
+	HeaderEIP4361 = "eip4361"
+	// HeaderCAIP122 is the header type of CACAOs of other namespaces
+	HeaderCAIP122 = "caip122"
+
+	// SignatureEIP191 is a personal_sign signature, hex encoded
+	SignatureEIP191 = "eip191"
+	// SignatureEIP1271 is a contract wallet signature, hex encoded
+	SignatureEIP1271 = "eip1271"
+	// SignatureSolanaEd25519 is an Ed25519 signature by a solana account
+	SignatureSolanaEd25519 = "solana:ed25519"
+)
+
+var (
+	ErrInvalidCACAO         = errors.New("invalid cacao")
+	ErrUnsupportedSignature = errors.New("unsupported signature type")
+)
+
+type CACAO struct {
+	Header    Header    `json:"h"`
+	Payload   Payload   `json:"p"`
+	Signature Signature `json:"s"`
+}
+
+type Header struct {
+	Type string `json:"t"`
+}
+
+// Payload holds the fields of a CAIP-122 message. Issuer is the did:pkh of the
+// signing account.
+type Payload struct {
+	Domain         string   `json:"domain"`
+	Issuer         string   `json:"iss"`
+	Audience       string   `json:"aud"`
+	Version        string   `json:"version"`
+	Nonce          string   `json:"nonce"`
+	IssuedAt       string   `json:"iat"`
+	NotBefore      string   `json:"nbf,omitempty"`
+	ExpirationTime string   `json:"exp,omitempty"`
+	Statement      string   `json:"statement,omitempty"`
+	RequestID      string   `json:"requestId,omitempty"`
+	Resources      []string `json:"resources,omitempty"`
+}
+
+type Signature struct {
+	Type string        `json:"t"`
+	Meta SignatureMeta `json:"m,omitempty"`
+	S    string        `json:"s"`
+}
+
+// SignatureMeta holds optional data of a signature type. Values are nil, bool,
+// int64, uint64, float64, string, []byte, []interface{} or
+// map[string]interface{}. JSON encodes []byte as a base64 string.
+type SignatureMeta map[string]interface{}
+
+func (m *SignatureMeta) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v map[string]interface{}
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+
+	meta, err := jsonNumbers(v)
+	if err != nil {
+		return err
+	}
+
+	*m, _ = meta.(map[string]interface{})
+	return nil
+}
+
+// jsonNumbers replaces the json.Number values in v with int64, uint64 or
+// float64 values, which encode to the same dag-cbor types.
+func jsonNumbers(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u, nil
+		}
+		return v.Float64()
+	case []interface{}:
+		for i, e := range v {
+			var err error
+			if v[i], err = jsonNumbers(e); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			var err error
+			if v[k], err = jsonNumbers(e); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// FromMessage converts a CAIP-122 message and its signature to a CACAO.
+// CACAOs have no field for the scheme of the message.
+func FromMessage(m siwx.Message, s Signature) (CACAO, error) {
+	if err := m.Validate(); err != nil {
+		return CACAO{}, err
+	}
+
+	if m.Scheme != "" {
+		return CACAO{}, fmt.Errorf("%w: message scheme is not supported: %s", ErrInvalidCACAO, m.Scheme)
+	}
+
+	h := Header{HeaderCAIP122}
+	if m.Account.ChainID.Namespace == "eip155" {
+		h.Type = HeaderEIP4361
+	}
+
+	return CACAO{
+		Header: h,
+		Payload: Payload{
+			Domain:         m.Domain,
+			Issuer:         m.Account.DID(),
+			Audience:       m.URI,
+			Version:        m.Version,
+			Nonce:          m.Nonce,
+			IssuedAt:       m.IssuedAt,
+			NotBefore:      m.NotBefore,
+			ExpirationTime: m.ExpirationTime,
+			Statement:      m.Statement,
+			RequestID:      m.RequestID,
+			Resources:      m.Resources,
+		},
+		Signature: s,
+	}, nil
+}
+
+// Account returns the account id of the issuer.
+func (p Payload) Account() (caip.AccountID, error) {
+	return caip.ParseDIDPKH(p.Issuer)
+}
+
+// Message converts the CACAO back to the signed CAIP-122 message.
+func (c CACAO) Message() (siwx.Message, error) {
+	if err := c.Validate(); err != nil {
+		return siwx.Message{}, err
+	}
+
+	account, _ := c.Payload.Account()
+	m := siwx.Message{
+		Domain:         c.Payload.Domain,
+		Account:        account,
+		Statement:      c.Payload.Statement,
+		URI:            c.Payload.Audience,
+		Version:        c.Payload.Version,
+		Nonce:          c.Payload.Nonce,
+		IssuedAt:       c.Payload.IssuedAt,
+		ExpirationTime: c.Payload.ExpirationTime,
+		NotBefore:      c.Payload.NotBefore,
+		RequestID:      c.Payload.RequestID,
+		Resources:      c.Payload.Resources,
+	}
+	if err := m.Validate(); err != nil {
+		return siwx.Message{}, err
+	}
+
+	return m, nil
+}
+
+// Validate checks the structure of the CACAO. Message validates the payload
+// fields.
+func (c CACAO) Validate() error {
+	account, err := c.Payload.Account()
+	if err != nil {
+		return fmt.Errorf("%w: invalid issuer: %v", ErrInvalidCACAO, err)
+	}
+
+	switch c.Header.Type {
+	case HeaderEIP4361:
+		if account.ChainID.Namespace != "eip155" {
+			return fmt.Errorf("%w: %s header for %s issuer", ErrInvalidCACAO, c.Header.Type, account.ChainID.Namespace)
+		}
+	case HeaderCAIP122:
+	default:
+		return fmt.Errorf("%w: unsupported header type: %s", ErrInvalidCACAO, c.Header.Type)
+	}
+
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{"p.domain", c.Payload.Domain},
+		{"p.aud", c.Payload.Audience},
+		{"p.version", c.Payload.Version},
+		{"p.nonce", c.Payload.Nonce},
+		{"p.iat", c.Payload.IssuedAt},
+		{"s.t", c.Signature.Type},
+		{"s.s", c.Signature.S},
+	} {
+		if f.value == "" {
+			return fmt.Errorf("%w: missing %s", ErrInvalidCACAO, f.name)
+		}
+	}
+
+	return nil
+}
+
+// Verify checks the signature of the message by the issuer. Only EIP-191
+// signatures by eip155 issuers are supported. Verify does not check the
+// expiration time and not before time, see siwx.Message.ValidAt.
+func (c CACAO) Verify() error {
+	m, err := c.Message()
+	if err != nil {
+		return err
+	}
+
+	if c.Signature.Type != SignatureEIP191 || m.Account.ChainID.Namespace != "eip155" {
+		return fmt.Errorf("%w: %s for %s issuer", ErrUnsupportedSignature, c.Signature.Type, m.Account.ChainID.Namespace)
+	}
+
+	sig, err := hexutil.Decode(c.Signature.S)
+	if err != nil {
+		return fmt.Errorf("%w: %v", siwx.ErrInvalidSignature, err)
+	}
+
+	return m.Verify(sig)
+}
+
+func (c *CACAO) UnmarshalJSON(data []byte) error {
+	type cacao CACAO
+	var cj cacao
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+
+	if err := CACAO(cj).Validate(); err != nil {
+		return err
+	}
+
+	*c = CACAO(cj)
+	return nil
+}
+
+func (c CACAO) MarshalJSON() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	type cacao CACAO
+	return json.Marshal(cacao(c))
+}
+
+// MarshalCBOR encodes the CACAO in dag-cbor.
+func (c CACAO) MarshalCBOR() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := map[string]interface{}{
+		"domain":  c.Payload.Domain,
+		"iss":     c.Payload.Issuer,
+		"aud":     c.Payload.Audience,
+		"version": c.Payload.Version,
+		"nonce":   c.Payload.Nonce,
+		"iat":     c.Payload.IssuedAt,
+	}
+	for key, value := range map[string]string{
+		"nbf":       c.Payload.NotBefore,
+		"exp":       c.Payload.ExpirationTime,
+		"statement": c.Payload.Statement,
+		"requestId": c.Payload.RequestID,
+	} {
+		if value != "" {
+			p[key] = value
+		}
+	}
+	if len(c.Payload.Resources) > 0 {
+		resources := make([]interface{}, len(c.Payload.Resources))
+		for i, r := range c.Payload.Resources {
+			resources[i] = r
+		}
+		p["resources"] = resources
+	}
+
+	sig := map[string]interface{}{"t": c.Signature.Type, "s": c.Signature.S}
+	if c.Signature.Meta != nil {
+		sig["m"] = map[string]interface{}(c.Signature.Meta)
+	}
+
+	return encodeCBOR(map[string]interface{}{
+		"h": map[string]interface{}{"t": c.Header.Type},
+		"p": p,
+		"s": sig,
+	})
+}
+
+// UnmarshalCBOR decodes a dag-cbor encoded CACAO.
+func (c *CACAO) UnmarshalCBOR(data []byte) error {
+	v, err := decodeCBOR(data)
+	if err != nil {
+		return err
+	}
+
+	d := cborFields{}
+	root := d.object("", v, "h", "p", "s")
+	h := d.object("h", root["h"], "t")
+	p := d.object("p", root["p"], "domain", "iss", "aud", "version", "nonce", "iat", "nbf", "exp", "statement", "requestId", "resources")
+	s := d.object("s", root["s"], "t", "m", "s")
+
+	cc := CACAO{
+		Header: Header{d.string("h.t", h["t"])},
+		Payload: Payload{
+			Domain:         d.string("p.domain", p["domain"]),
+			Issuer:         d.string("p.iss", p["iss"]),
+			Audience:       d.string("p.aud", p["aud"]),
+			Version:        d.string("p.version", p["version"]),
+			Nonce:          d.string("p.nonce", p["nonce"]),
+			IssuedAt:       d.string("p.iat", p["iat"]),
+			NotBefore:      d.string("p.nbf", p["nbf"]),
+			ExpirationTime: d.string("p.exp", p["exp"]),
+			Statement:      d.string("p.statement", p["statement"]),
+			RequestID:      d.string("p.requestId", p["requestId"]),
+			Resources:      d.strings("p.resources", p["resources"]),
+		},
+		Signature: Signature{
+			Type: d.string("s.t", s["t"]),
+			Meta: d.meta("s.m", s["m"]),
+			S:    d.string("s.s", s["s"]),
+		},
+	}
+	if d.err != nil {
+		return d.err
+	}
+
+	if err := cc.Validate(); err != nil {
+		return err
+	}
+
+	*c = cc
+	return nil
+}
+
+// cborFields converts decoded dag-cbor values to fields, recording the first
+// error. Missing values are nil.
+type cborFields struct {
+	err error
+}
+
+func (d *cborFields) errorf(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s", ErrInvalidCACAO, fmt.Sprintf(format, args...))
+	}
+}
+
+func (d *cborFields) object(name string, v interface{}, keys ...string) map[string]interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		d.errorf("%s is not a map", name)
+		return nil
+	}
+
+	known := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		known[k] = true
+	}
+	for k := range m {
+		if !known[k] {
+			d.errorf("unsupported field: %s", joinField(name, k))
+		}
+	}
+
+	return m
+}
+
+func joinField(name, key string) string {
+	if name == "" {
+		return key
+	}
+	return name + "." + key
+}
+
+func (d *cborFields) string(name string, v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		d.errorf("%s is not a string", name)
+	}
+	return s
+}
+
+func (d *cborFields) meta(name string, v interface{}) SignatureMeta {
+	if v == nil {
+		return nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		d.errorf("%s is not a map", name)
+	}
+	return m
+}
+
+func (d *cborFields) strings(name string, v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+
+	a, ok := v.([]interface{})
+	if !ok {
+		d.errorf("%s is not a list", name)
+		return nil
+	}
+
+	ss := make([]string, len(a))
+	for i, e := range a {
+		ss[i] = d.string(fmt.Sprintf("%s[%d]", name, i), e)
+	}
+	return ss
+}
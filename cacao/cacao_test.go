@@ -0,0 +1,280 @@
+package cacao
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ChainAgnostic/go-caip/siwx"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const message = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Expiration Time: 2021-10-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+// signedCACAO returns the CACAO of message signed by a new key.
+func signedCACAO(t *testing.T) CACAO {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := siwx.Parse(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Account.Address = crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	sig, err := crypto.Sign(siwx.PersonalSignHash(m.String()), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[64] += 27
+
+	c, err := FromMessage(m, Signature{Type: SignatureEIP191, S: hexutil.Encode(sig)})
+	if err != nil {
+		t.Fatalf("Failed to convert message: %v", err)
+	}
+
+	return c
+}
+
+func TestFromMessage(t *testing.T) {
+	m, _ := siwx.Parse(message)
+	c, err := FromMessage(m, Signature{Type: SignatureEIP191, S: "0x00"})
+	if err != nil {
+		t.Fatalf("Failed to convert message: %v", err)
+	}
+
+	if c.Header.Type != HeaderEIP4361 {
+		t.Errorf("Header type invalid: %s", c.Header.Type)
+	}
+
+	if c.Payload.Issuer != "did:pkh:eip155:1:0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2" || c.Payload.Audience != m.URI || c.Payload.IssuedAt != m.IssuedAt {
+		t.Errorf("Payload invalid: %+v", c.Payload)
+	}
+
+	m2, err := c.Message()
+	if err != nil {
+		t.Fatalf("Failed to convert to message: %v", err)
+	}
+
+	if m2.String() != message {
+		t.Errorf("Converted message differs:\n%s", m2)
+	}
+
+	m.Scheme = "https"
+	if _, err := FromMessage(m, Signature{Type: SignatureEIP191, S: "0x00"}); !errors.Is(err, ErrInvalidCACAO) {
+		t.Errorf("Converting message with scheme should fail, got: %v", err)
+	}
+
+	m, _ = siwx.Parse(strings.NewReplacer(
+		"Ethereum", "Solana",
+		"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "GwHH8ciFhR8vejWCqmg8FWZUCNtubPY2esALvy5tBvji",
+		"Chain ID: 1", "Chain ID: 5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp",
+	).Replace(message))
+	c, err = FromMessage(m, Signature{Type: SignatureSolanaEd25519, S: "sig"})
+	if err != nil {
+		t.Fatalf("Failed to convert solana message: %v", err)
+	}
+
+	if c.Header.Type != HeaderCAIP122 {
+		t.Errorf("Header type invalid: %s", c.Header.Type)
+	}
+
+	if err := c.Verify(); !errors.Is(err, ErrUnsupportedSignature) {
+		t.Errorf("Verify of solana signature should fail with unsupported signature, got: %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	c := signedCACAO(t)
+	if err := c.Verify(); err != nil {
+		t.Fatalf("Failed to verify cacao: %v", err)
+	}
+
+	tampered := c
+	tampered.Payload.Nonce = "87654321"
+	if err := tampered.Verify(); !errors.Is(err, siwx.ErrInvalidSignature) {
+		t.Errorf("Verify of tampered cacao should fail, got: %v", err)
+	}
+
+	tampered = c
+	tampered.Signature.S = "0xzz"
+	if err := tampered.Verify(); !errors.Is(err, siwx.ErrInvalidSignature) {
+		t.Errorf("Verify of invalid hex signature should fail, got: %v", err)
+	}
+
+	tampered = c
+	tampered.Signature.Type = SignatureEIP1271
+	if err := tampered.Verify(); !errors.Is(err, ErrUnsupportedSignature) {
+		t.Errorf("Verify of eip1271 signature should fail with unsupported signature, got: %v", err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	c := signedCACAO(t)
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	for _, key := range []string{`"h":{"t":"eip4361"}`, `"iss":"did:pkh:eip155:1:`, `"aud":"https://service.invalid/login"`, `"exp":"2021-10-30T16:25:24Z"`, `"s":{"t":"eip191","s":"0x`} {
+		if !strings.Contains(string(b), key) {
+			t.Errorf("JSON missing %s: %s", key, b)
+		}
+	}
+
+	if strings.Contains(string(b), "nbf") {
+		t.Errorf("JSON should omit empty fields: %s", b)
+	}
+
+	c2 := CACAO{}
+	if err := json.Unmarshal(b, &c2); err != nil {
+		t.Fatalf("Failed to unmarshal from json: %v", err)
+	}
+
+	if !reflect.DeepEqual(c2, c) {
+		t.Errorf("Unmarshalled cacao differs: %+v", c2)
+	}
+
+	if err := c2.Verify(); err != nil {
+		t.Errorf("Failed to verify unmarshalled cacao: %v", err)
+	}
+
+	for _, data := range []string{
+		`{"h":{"t":"jwt"},"p":{"domain":"a","iss":"did:pkh:eip155:1:0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2","aud":"b","version":"1","nonce":"c","iat":"d"},"s":{"t":"eip191","s":"0x00"}}`,
+		`{"h":{"t":"eip4361"},"p":{"domain":"a","iss":"did:key:z6Mk","aud":"b","version":"1","nonce":"c","iat":"d"},"s":{"t":"eip191","s":"0x00"}}`,
+		`{"h":{"t":"eip4361"},"p":{"domain":"a","iss":"did:pkh:solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:GwHH8ciFhR8vejWCqmg8FWZUCNtubPY2esALvy5tBvji","aud":"b","version":"1","nonce":"c","iat":"d"},"s":{"t":"eip191","s":"0x00"}}`,
+		`{"h":{"t":"eip4361"},"p":{"domain":"a","iss":"did:pkh:eip155:1:0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2","aud":"b","version":"1","nonce":"c"},"s":{"t":"eip191","s":"0x00"}}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &c2); !errors.Is(err, ErrInvalidCACAO) {
+			t.Errorf("Unmarshal of %s should fail with invalid cacao, got: %v", data, err)
+		}
+	}
+}
+
+func TestCBOR(t *testing.T) {
+	c := signedCACAO(t)
+
+	b, err := c.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("Failed to marshal to cbor: %v", err)
+	}
+
+	// {"h": {"t": "eip4361"}, "p": {"aud": ...
+	if prefix := "a36168a16174676569703433363161" + "70" + "a9" + "63617564"; !strings.HasPrefix(hex.EncodeToString(b), prefix) {
+		t.Errorf("CBOR invalid: %x", b)
+	}
+
+	c2 := CACAO{}
+	if err := c2.UnmarshalCBOR(b); err != nil {
+		t.Fatalf("Failed to unmarshal from cbor: %v", err)
+	}
+
+	if !reflect.DeepEqual(c2, c) {
+		t.Errorf("Unmarshalled cacao differs: %+v", c2)
+	}
+
+	if err := c2.Verify(); err != nil {
+		t.Errorf("Failed to verify unmarshalled cacao: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		v    map[string]interface{}
+	}{
+		{"unsupported field", map[string]interface{}{"h": map[string]interface{}{"t": "eip4361"}, "p": map[string]interface{}{}, "s": map[string]interface{}{}, "x": ""}},
+		{"header not a map", map[string]interface{}{"h": "eip4361", "p": map[string]interface{}{}, "s": map[string]interface{}{}}},
+		{"missing payload", map[string]interface{}{"h": map[string]interface{}{"t": "eip4361"}, "s": map[string]interface{}{}}},
+		{"meta not a map", map[string]interface{}{"h": map[string]interface{}{"t": "eip4361"}, "p": map[string]interface{}{}, "s": map[string]interface{}{"m": "a"}}},
+		{"resources not a list", map[string]interface{}{"h": map[string]interface{}{"t": "eip4361"}, "p": map[string]interface{}{"resources": "a"}, "s": map[string]interface{}{}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := encodeCBOR(tc.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c2.UnmarshalCBOR(b); !errors.Is(err, ErrInvalidCACAO) {
+				t.Errorf("UnmarshalCBOR should fail with invalid cacao, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestSignatureMeta(t *testing.T) {
+	c := signedCACAO(t)
+	c.Signature.Meta = SignatureMeta{
+		"domain":  "example.com",
+		"version": int64(2),
+		"big":     uint64(1 << 63),
+		"ratio":   0.5,
+		"ok":      true,
+		"none":    nil,
+		"list":    []interface{}{"a", int64(-1)},
+		"nested":  map[string]interface{}{"k": "v"},
+	}
+
+	b, err := c.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("Failed to marshal to cbor: %v", err)
+	}
+
+	c2 := CACAO{}
+	if err := c2.UnmarshalCBOR(b); err != nil {
+		t.Fatalf("Failed to unmarshal from cbor: %v", err)
+	}
+
+	if !reflect.DeepEqual(c2, c) {
+		t.Errorf("Unmarshalled cbor signature meta differs: %#v", c2.Signature.Meta)
+	}
+
+	j, err := json.Marshal(c2)
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	c3 := CACAO{}
+	if err := json.Unmarshal(j, &c3); err != nil {
+		t.Fatalf("Failed to unmarshal from json: %v", err)
+	}
+
+	if !reflect.DeepEqual(c3, c) {
+		t.Errorf("Unmarshalled json signature meta differs: %#v", c3.Signature.Meta)
+	}
+
+	b3, err := c3.MarshalCBOR()
+	if err != nil || !bytes.Equal(b3, b) {
+		t.Errorf("CBOR differs after json round trip: %x", b3)
+	}
+
+	if err := c3.Verify(); err != nil {
+		t.Errorf("Failed to verify cacao with signature meta: %v", err)
+	}
+
+	c.Signature.Meta = SignatureMeta{"ch": make(chan int)}
+	if _, err := c.MarshalCBOR(); !errors.Is(err, ErrCBOR) {
+		t.Errorf("Marshal of unsupported meta value should fail, got: %v", err)
+	}
+}
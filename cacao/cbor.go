@@ -0,0 +1,290 @@
+package cacao
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf8"
+)
+
+// CACAOs are encoded here with a tree of string, []interface{} and
+// map[string]interface{} values. Signature metadata may also hold null, bool,
+// int64, uint64, float64 and []byte values. Links (CIDs) are not supported.
+
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorSimple = 7
+
+	simpleFalse   = 20
+	simpleTrue    = 21
+	simpleNull    = 22
+	simpleFloat64 = 27
+)
+
+var ErrCBOR = errors.New("invalid dag-cbor")
+
+func encodeCBOR(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	if err := writeCBOR(&b, v); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func writeCBOR(b *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		b.WriteByte(majorSimple<<5 | simpleNull)
+	case bool:
+		if v {
+			b.WriteByte(majorSimple<<5 | simpleTrue)
+		} else {
+			b.WriteByte(majorSimple<<5 | simpleFalse)
+		}
+	case int:
+		return writeCBOR(b, int64(v))
+	case int64:
+		if v < 0 {
+			writeHead(b, majorNegInt, uint64(-(v + 1)))
+		} else {
+			writeHead(b, majorUint, uint64(v))
+		}
+	case uint64:
+		writeHead(b, majorUint, v)
+	case float64:
+		// dag-cbor encodes all floats in 64 bits and has no NaN or infinities
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: unsupported float %v", ErrCBOR, v)
+		}
+		b.WriteByte(majorSimple<<5 | simpleFloat64)
+		_ = binary.Write(b, binary.BigEndian, math.Float64bits(v))
+	case []byte:
+		writeHead(b, majorBytes, uint64(len(v)))
+		b.Write(v)
+	case string:
+		writeHead(b, majorText, uint64(len(v)))
+		b.WriteString(v)
+	case []interface{}:
+		writeHead(b, majorArray, uint64(len(v)))
+		for _, e := range v {
+			if err := writeCBOR(b, e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		// dag-cbor sorts map keys by length, then bytewise
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+
+		writeHead(b, majorMap, uint64(len(v)))
+		for _, k := range keys {
+			writeHead(b, majorText, uint64(len(k)))
+			b.WriteString(k)
+			if err := writeCBOR(b, v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported value %T", ErrCBOR, v)
+	}
+
+	return nil
+}
+
+// writeHead writes the shortest head of a data item.
+func writeHead(b *bytes.Buffer, major byte, n uint64) {
+	major <<= 5
+	switch {
+	case n < 24:
+		b.WriteByte(major | byte(n))
+	case n <= 0xff:
+		b.Write([]byte{major | 24, byte(n)})
+	case n <= 0xffff:
+		b.WriteByte(major | 25)
+		_ = binary.Write(b, binary.BigEndian, uint16(n))
+	case n <= 0xffffffff:
+		b.WriteByte(major | 26)
+		_ = binary.Write(b, binary.BigEndian, uint32(n))
+	default:
+		b.WriteByte(major | 27)
+		_ = binary.Write(b, binary.BigEndian, n)
+	}
+}
+
+func decodeCBOR(data []byte) (interface{}, error) {
+	d := cborDecoder{data: data}
+	v, err := d.value(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.i != len(data) {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrCBOR, len(data)-d.i)
+	}
+
+	return v, nil
+}
+
+// maxDepth limits the nesting of decoded values.
+const maxDepth = 16
+
+type cborDecoder struct {
+	data []byte
+	i    int
+}
+
+func (d *cborDecoder) head() (byte, byte, uint64, error) {
+	if d.i >= len(d.data) {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of data", ErrCBOR)
+	}
+
+	major, info := d.data[d.i]>>5, d.data[d.i]&0x1f
+	d.i++
+
+	if info < 24 {
+		return major, info, uint64(info), nil
+	}
+
+	size := 0
+	switch info {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: unsupported additional information %d", ErrCBOR, info)
+	}
+
+	if len(d.data)-d.i < size {
+		return 0, 0, 0, fmt.Errorf("%w: unexpected end of data", ErrCBOR)
+	}
+
+	var n uint64
+	for _, c := range d.data[d.i : d.i+size] {
+		n = n<<8 | uint64(c)
+	}
+	d.i += size
+
+	// dag-cbor requires the shortest encoding, floats are always 64 bits
+	if major != majorSimple && (n < 24 || (size > 1 && n>>(uint(size)*4) == 0)) {
+		return 0, 0, 0, fmt.Errorf("%w: non-minimal length", ErrCBOR)
+	}
+
+	return major, info, n, nil
+}
+
+func (d *cborDecoder) value(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested too deeply", ErrCBOR)
+	}
+
+	major, info, n, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUint:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case majorNegInt:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: integer out of range", ErrCBOR)
+		}
+		return -int64(n) - 1, nil
+	case majorBytes:
+		if n > uint64(len(d.data)-d.i) {
+			return nil, fmt.Errorf("%w: unexpected end of data", ErrCBOR)
+		}
+		b := append([]byte(nil), d.data[d.i:d.i+int(n)]...)
+		d.i += int(n)
+		return b, nil
+	case majorText:
+		if n > uint64(len(d.data)-d.i) {
+			return nil, fmt.Errorf("%w: unexpected end of data", ErrCBOR)
+		}
+		s := string(d.data[d.i : d.i+int(n)])
+		d.i += int(n)
+		if !utf8.ValidString(s) {
+			return nil, fmt.Errorf("%w: invalid utf-8 string", ErrCBOR)
+		}
+		return s, nil
+	case majorArray:
+		// every item is at least one byte
+		if n > uint64(len(d.data)-d.i) {
+			return nil, fmt.Errorf("%w: unexpected end of data", ErrCBOR)
+		}
+		a := make([]interface{}, 0, n)
+		for j := uint64(0); j < n; j++ {
+			e, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, e)
+		}
+		return a, nil
+	case majorMap:
+		if n > uint64(len(d.data)-d.i) {
+			return nil, fmt.Errorf("%w: unexpected end of data", ErrCBOR)
+		}
+		m := make(map[string]interface{}, n)
+		for j := uint64(0); j < n; j++ {
+			k, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: map key is not a string", ErrCBOR)
+			}
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("%w: duplicate map key %q", ErrCBOR, key)
+			}
+			if m[key], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case majorSimple:
+		switch info {
+		case simpleFalse:
+			return false, nil
+		case simpleTrue:
+			return true, nil
+		case simpleNull:
+			return nil, nil
+		case simpleFloat64:
+			f := math.Float64frombits(n)
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("%w: unsupported float %v", ErrCBOR, f)
+			}
+			return f, nil
+		default:
+			return nil, fmt.Errorf("%w: unsupported simple value %d", ErrCBOR, info)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported major type %d", ErrCBOR, major)
+	}
+}
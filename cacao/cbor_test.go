@@ -0,0 +1,99 @@
+package cacao
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeCBOR(t *testing.T) {
+	for _, tc := range []struct {
+		v    interface{}
+		want string
+	}{
+		{nil, "f6"},
+		{true, "f5"},
+		{false, "f4"},
+		{int64(0), "00"},
+		{int64(24), "1818"},
+		{int64(-1), "20"},
+		{int64(-500), "3901f3"},
+		{uint64(1 << 63), "1b8000000000000000"},
+		{1.5, "fb3ff8000000000000"},
+		{[]byte{1, 2}, "420102"},
+		{"", "60"},
+		{"eip4361", "6765697034333631"},
+		{strings.Repeat("a", 24), "7818" + strings.Repeat("61", 24)},
+		{strings.Repeat("a", 256), "790100" + strings.Repeat("61", 256)},
+		{[]interface{}{"a", "b"}, "82616161" + "62"},
+		{map[string]interface{}{"t": "eip4361"}, "a16174" + "6765697034333631"},
+		// length first, then bytewise
+		{map[string]interface{}{"bb": "", "a": "", "c": ""}, "a3" + "616160" + "616360" + "62626260"},
+	} {
+		b, err := encodeCBOR(tc.v)
+		if err != nil {
+			t.Fatalf("Failed to encode %v: %v", tc.v, err)
+		}
+
+		if got := hex.EncodeToString(b); got != tc.want {
+			t.Errorf("encodeCBOR(%v): expected %s, got %s", tc.v, tc.want, got)
+		}
+
+		v, err := decodeCBOR(b)
+		if err != nil {
+			t.Errorf("Failed to decode %s: %v", tc.want, err)
+		}
+
+		if !reflect.DeepEqual(v, tc.v) {
+			t.Errorf("decodeCBOR(%s): expected %v, got %v", tc.want, tc.v, v)
+		}
+	}
+}
+
+func TestDecodeCBORInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"truncated string", "6361"},
+		{"truncated head", "79"},
+		{"truncated map", "a16174"},
+		{"non-minimal", "780161"},
+		{"non-minimal 16 bit", "79000161"},
+		{"indefinite", "7f6161ff"},
+		{"trailing", "6060"},
+		{"non-minimal integer", "1817"},
+		{"negative integer out of range", "3bffffffffffffffff"},
+		{"float32", "fa3fc00000"},
+		{"undefined", "f7"},
+		{"nan", "fb7ff8000000000000"},
+		{"tag", "d82a60"},
+		{"invalid utf-8", "61ff"},
+		{"integer key", "a10160"},
+		{"truncated bytes", "4201"},
+		{"duplicate key", "a2616160616160"},
+		{"huge array", "9b00000000ffffffff"},
+		{"nested", strings.Repeat("81", maxDepth+2) + "60"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, _ := hex.DecodeString(tc.data)
+			if _, err := decodeCBOR(b); !errors.Is(err, ErrCBOR) {
+				t.Errorf("decodeCBOR should fail with invalid dag-cbor, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestEncodeCBORDeterministic(t *testing.T) {
+	v := map[string]interface{}{"domain": "", "iss": "", "aud": "", "version": "", "nonce": "", "iat": "", "statement": "", "requestId": "", "resources": []interface{}{}}
+	first, _ := encodeCBOR(v)
+	for i := 0; i < 10; i++ {
+		if b, _ := encodeCBOR(v); !bytes.Equal(b, first) {
+			t.Fatalf("Encoding is not deterministic")
+		}
+	}
+}
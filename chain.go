@@ -1,14 +1,10 @@
 package caip
 
 import (
-	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"regexp"
-	"strconv"
 	"strings"
 )
 
@@ -17,11 +13,6 @@ type ChainID struct {
 	Reference string `json:"reference"`
 }
 
-var (
-	chainNamespaceRegex = regexp.MustCompile("[-a-z0-9]{3,8}")
-	chainReferenceRegex = regexp.MustCompile("[-a-zA-Z0-9]{1,32}")
-)
-
 func NewChainID(namespace, reference string) (ChainID, error) {
 	cID := ChainID{namespace, reference}
 	if err := cID.Validate(); err != nil {
@@ -36,38 +27,100 @@ func UnsafeChainID(namespace, reference string) ChainID {
 }
 
 func (c ChainID) Validate() error {
-	if ok := chainNamespaceRegex.Match([]byte(c.Namespace)); !ok {
-		return errors.New("chain namespace does not match spec")
+	return c.validate(0)
+}
+
+func (c ChainID) validate(offset int) error {
+	return c.validateGrammar(offset, c.grammar())
+}
+
+func (c ChainID) grammar() grammar {
+	var g grammar
+	g = g.set(chainNamespaceOK, validChainNamespace(c.Namespace))
+	g = g.set(chainReferenceOK, validChainReference(c.Reference))
+	return g
+}
+
+// validateGrammar reports the first component not matched in g, which is
+// computed by grammar or while parsing, and then applies the namespace
+// profile.
+func (c ChainID) validateGrammar(offset int, g grammar) error {
+	if g&chainNamespaceOK == 0 {
+		return &ValidationError{ChainIDKind, "namespace", c.Namespace, offset, ErrNamespaceInvalid}
+	}
+
+	offset += len(c.Namespace) + 1
+	if g&chainReferenceOK == 0 {
+		return &ValidationError{ChainIDKind, "reference", c.Reference, offset, ErrReferenceInvalid}
 	}
 
-	if ok := chainReferenceRegex.Match([]byte(c.Reference)); !ok {
-		return errors.New("chain reference does not match spec")
+	if ns, ok := LookupNamespace(c.Namespace); ok {
+		if err := ns.ValidateReference(c.Reference); err != nil {
+			return &ValidationError{ChainIDKind, "reference", c.Reference, offset, namespaceError(ErrReferenceInvalid, err)}
+		}
 	}
 
 	return nil
 }
 
+// String returns the CAIP string form of the chain id without validating it,
+// so it is safe to use on zero values and unsafe chain ids. Use Canonical for
+// validated output.
 func (c ChainID) String() string {
+	return c.Namespace + ":" + c.Reference
+}
+
+// Canonical returns the CAIP string form of a valid chain id.
+func (c ChainID) Canonical() (string, error) {
 	if err := c.Validate(); err != nil {
-		panic(err)
+		return "", err
 	}
-	return c.Namespace + ":" + c.Reference
+	return c.String(), nil
+}
+
+func (c ChainID) Equal(other ChainID) bool {
+	return c == other
+}
+
+// Compare orders chain ids by namespace and then reference, returning -1, 0
+// or 1.
+func (c ChainID) Compare(other ChainID) int {
+	if n := strings.Compare(c.Namespace, other.Namespace); n != 0 {
+		return n
+	}
+	return strings.Compare(c.Reference, other.Reference)
+}
+
+// Format implements fmt.Formatter. %+v prints the components of the chain id.
+func (c ChainID) Format(f fmt.State, verb rune) {
+	type chainIDFormat ChainID
+	format(f, verb, "ChainID", c.String(), chainIDFormat(c))
 }
 
 func (c *ChainID) Parse(s string) error {
-	split := strings.SplitN(s, ":", 2)
-	if len(split) != 2 {
-		return fmt.Errorf("invalid chain id: %s", s)
+	var g grammar
+	nsEnd, ok, found := component(s, 0, ':', ':', namespaceChars, 3, 8)
+	if !found {
+		return malformedError(ChainIDKind, s)
 	}
+	g = g.set(chainNamespaceOK, ok)
+	g = g.set(chainReferenceOK, validChainReference(s[nsEnd+1:]))
 
-	*c = ChainID{split[0], split[1]}
-	if err := c.Validate(); err != nil {
+	*c = ChainID{s[:nsEnd], s[nsEnd+1:]}
+	if err := c.validateGrammar(0, g); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// ParseBytes is like Parse but does not allocate: the components of the chain
+// id share memory with b, which must not be modified afterwards. Use
+// Parse(string(b)) when b is reused.
+func (c *ChainID) ParseBytes(b []byte) error {
+	return c.Parse(unsafeString(b))
+}
+
 func (c *ChainID) ParseX(s string) {
 	if err := c.Parse(s); err != nil {
 		panic(err)
@@ -75,6 +128,10 @@ func (c *ChainID) ParseX(s string) {
 }
 
 func (c *ChainID) UnmarshalJSON(data []byte) error {
+	if ok, err := unmarshalJSONString(data, c.Parse); ok {
+		return err
+	}
+
 	type ChainIDAlias ChainID
 	ca := (*ChainIDAlias)(c)
 	if err := json.Unmarshal(data, &ca); err != nil {
@@ -98,21 +155,33 @@ func (c ChainID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ca)
 }
 
-func (c ChainID) Value() (driver.Value, error) {
-	return c.String(), nil
+func (c ChainID) MarshalText() ([]byte, error) {
+	s, err := c.Canonical()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
 }
 
-func (c *ChainID) Scan(src interface{}) error {
-	var i sql.NullString
-	if err := i.Scan(src); err != nil {
-		return fmt.Errorf("scanning chain id: %w", err)
+func (c *ChainID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, c.Parse)
+}
+
+func (c ChainID) Value() (driver.Value, error) {
+	s, err := c.Canonical()
+	if err != nil {
+		return nil, err
 	}
+	return s, nil
+}
 
-	if !i.Valid {
-		return nil
+func (c *ChainID) Scan(src interface{}) error {
+	s, err := scanString(ChainIDKind, src)
+	if err != nil {
+		return err
 	}
 
-	if err := c.Parse(i.String); err != nil {
+	if err := c.Parse(s); err != nil {
 		return err
 	}
 
@@ -120,15 +189,9 @@ func (c *ChainID) Scan(src interface{}) error {
 }
 
 func (c ChainID) MarshalGQL(w io.Writer) {
-	fmt.Fprint(w, strconv.Quote(strings.ToUpper(c.String())))
+	marshalGQL(w, c.String())
 }
 
 func (c *ChainID) UnmarshalGQL(v interface{}) error {
-	if id, ok := v.(string); ok {
-		if err := c.Parse(id); err != nil {
-			return fmt.Errorf("unmarshalling account id: %w", err)
-		}
-	}
-
-	return nil
+	return unmarshalGQL(ChainIDKind, v, c.Parse)
 }
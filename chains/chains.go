@@ -0,0 +1,272 @@
+// Package chains is a registry of chain metadata keyed by CAIP-2 chain id.
+//
+// The registry reads the chainlist format (https://chainid.network/chains.json)
+// with three optional extensions: "caip2" for chains outside of eip155,
+// "testnet" to flag test networks explicitly, and "aliases" for lookups by
+// name. Without "testnet", chains with slip44 coin type 1 are test networks,
+// as in chainlist. A dataset of well-known chains is embedded and can be extended or
+// overridden with LoadFile.
+package chains
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	caip "github.com/ChainAgnostic/go-caip"
+)
+
+type Currency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+type Explorer struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Standard string `json:"standard,omitempty"`
+}
+
+type Chain struct {
+	ID             caip.ChainID
+	Name           string
+	ShortName      string
+	NativeCurrency Currency
+	// NativeAsset is the slip44 asset id of the native currency, if known
+	NativeAsset *caip.AssetID
+	Explorers   []Explorer
+	Testnet     bool
+	// Parent is set for L2s and other chains settling on another chain
+	Parent     *caip.ChainID
+	ParentType string
+	Aliases    []string
+}
+
+// entry is a chain in the chainlist format.
+type entry struct {
+	Name           string     `json:"name"`
+	ShortName      string     `json:"shortName"`
+	ChainID        *uint64    `json:"chainId"`
+	CAIP2          string     `json:"caip2"`
+	Slip44         *uint32    `json:"slip44"`
+	NativeCurrency Currency   `json:"nativeCurrency"`
+	Explorers      []Explorer `json:"explorers"`
+	Parent         *struct {
+		Type  string `json:"type"`
+		Chain string `json:"chain"`
+	} `json:"parent"`
+	Testnet *bool    `json:"testnet"`
+	Aliases []string `json:"aliases"`
+}
+
+func (e entry) chain() (Chain, error) {
+	c := Chain{
+		Name:           e.Name,
+		ShortName:      e.ShortName,
+		NativeCurrency: e.NativeCurrency,
+		Explorers:      e.Explorers,
+		Aliases:        e.Aliases,
+	}
+
+	switch {
+	case e.CAIP2 != "":
+		if err := c.ID.Parse(e.CAIP2); err != nil {
+			return Chain{}, fmt.Errorf("chain %s: %w", e.Name, err)
+		}
+	case e.ChainID != nil:
+		c.ID = caip.UnsafeChainID("eip155", strconv.FormatUint(*e.ChainID, 10))
+	default:
+		return Chain{}, fmt.Errorf("chain %s: missing chain id", e.Name)
+	}
+
+	if e.Slip44 != nil {
+		aID, err := caip.NewAssetID(c.ID, "slip44", strconv.FormatUint(uint64(*e.Slip44), 10))
+		if err != nil {
+			return Chain{}, fmt.Errorf("chain %s: %w", e.Name, err)
+		}
+		c.NativeAsset = &aID
+	} else if native, err := caip.NativeAsset(c.ID); err == nil {
+		c.NativeAsset = &native.AssetID
+	}
+
+	if e.Testnet != nil {
+		c.Testnet = *e.Testnet
+	} else {
+		c.Testnet = e.Slip44 != nil && *e.Slip44 == 1
+	}
+
+	if e.Parent != nil {
+		// chainlist refers to parent chains as "eip155-1"
+		parent := caip.ChainID{}
+		if err := parent.Parse(strings.Replace(e.Parent.Chain, "-", ":", 1)); err != nil {
+			return Chain{}, fmt.Errorf("chain %s parent: %w", e.Name, err)
+		}
+		c.Parent = &parent
+		c.ParentType = e.Parent.Type
+	}
+
+	return c, nil
+}
+
+type Registry struct {
+	mu      sync.RWMutex
+	chains  map[caip.ChainID]Chain
+	aliases map[string]caip.ChainID
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		chains:  map[caip.ChainID]Chain{},
+		aliases: map[string]caip.ChainID{},
+	}
+}
+
+// LoadError reports the entries skipped by Load.
+type LoadError struct {
+	Errs []error
+}
+
+func (e *LoadError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("skipped %d chains: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// Load reads chains in the chainlist format, replacing chains with the same id.
+// Malformed entries are skipped, the other chains are added and the skipped
+// entries are reported in a *LoadError.
+func (r *Registry) Load(rd io.Reader) error {
+	var entries []json.RawMessage
+	if err := json.NewDecoder(rd).Decode(&entries); err != nil {
+		return fmt.Errorf("decoding chains: %w", err)
+	}
+
+	var errs []error
+	for i, data := range entries {
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			errs = append(errs, fmt.Errorf("chain %d: %w", i, err))
+			continue
+		}
+
+		c, err := e.chain()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.Add(c)
+	}
+
+	if len(errs) > 0 {
+		return &LoadError{errs}
+	}
+
+	return nil
+}
+
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return r.Load(f)
+}
+
+func (r *Registry) Add(c Chain) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.chains[c.ID]; ok {
+		for _, alias := range old.aliases() {
+			delete(r.aliases, alias)
+		}
+	}
+
+	r.chains[c.ID] = c
+	for _, alias := range c.aliases() {
+		r.aliases[alias] = c.ID
+	}
+}
+
+func (r *Registry) Lookup(id caip.ChainID) (Chain, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.chains[id]
+	return c, ok
+}
+
+// LookupAlias finds a chain by one of its aliases or its short name, ignoring
+// case.
+func (r *Registry) LookupAlias(alias string) (Chain, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.aliases[strings.ToLower(alias)]
+	if !ok {
+		return Chain{}, false
+	}
+	return r.chains[id], true
+}
+
+func (r *Registry) Chains() []Chain {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chains := make([]Chain, 0, len(r.chains))
+	for _, c := range r.chains {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].ID.String() < chains[j].ID.String()
+	})
+	return chains
+}
+
+func (c Chain) aliases() []string {
+	aliases := make([]string, 0, len(c.Aliases)+1)
+	if c.ShortName != "" {
+		aliases = append(aliases, strings.ToLower(c.ShortName))
+	}
+	for _, alias := range c.Aliases {
+		aliases = append(aliases, strings.ToLower(alias))
+	}
+	return aliases
+}
+
+var (
+	//go:embed chains.json
+	chainsData []byte
+
+	Default = NewRegistry()
+)
+
+func init() {
+	// Errors in the embedded data fail TestEmbeddedChains rather than every
+	// importer
+	_ = Default.Load(bytes.NewReader(chainsData))
+}
+
+func Lookup(id caip.ChainID) (Chain, bool) {
+	return Default.Lookup(id)
+}
+
+func LookupAlias(alias string) (Chain, bool) {
+	return Default.LookupAlias(alias)
+}
+
+// LoadFile loads chains from a local chainlist file into the default registry,
+// overriding embedded chains with the same id.
+func LoadFile(path string) error {
+	return Default.LoadFile(path)
+}
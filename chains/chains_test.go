@@ -0,0 +1,223 @@
+package chains
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	caip "github.com/ChainAgnostic/go-caip"
+)
+
+func TestLookup(t *testing.T) {
+	for _, tc := range []struct {
+		id        string
+		name      string
+		symbol    string
+		decimals  int
+		native    string
+		testnet   bool
+		parent    string
+		explorers int
+	}{{
+		id:        "eip155:1",
+		name:      "Ethereum Mainnet",
+		symbol:    "ETH",
+		decimals:  18,
+		native:    "eip155:1/slip44:60",
+		explorers: 1,
+	}, {
+		id:        "eip155:137",
+		name:      "Polygon Mainnet",
+		symbol:    "MATIC",
+		decimals:  18,
+		native:    "eip155:137/slip44:966",
+		explorers: 1,
+	}, {
+		// Native asset from the slip44 table
+		id:        "eip155:42161",
+		name:      "Arbitrum One",
+		symbol:    "ETH",
+		decimals:  18,
+		native:    "eip155:42161/slip44:60",
+		parent:    "eip155:1",
+		explorers: 1,
+	}, {
+		// Lists a faucet but is not a testnet
+		id:        "eip155:100",
+		name:      "Gnosis",
+		symbol:    "XDAI",
+		decimals:  18,
+		native:    "eip155:100/slip44:700",
+		explorers: 1,
+	}, {
+		// Testnet by its slip44 coin type
+		id:        "eip155:80002",
+		name:      "Polygon Amoy Testnet",
+		symbol:    "POL",
+		decimals:  18,
+		native:    "eip155:80002/slip44:1",
+		testnet:   true,
+		explorers: 1,
+	}, {
+		id:        "bip122:000000000019d6689c085ae165831e93",
+		name:      "Bitcoin",
+		symbol:    "BTC",
+		decimals:  8,
+		native:    "bip122:000000000019d6689c085ae165831e93/slip44:0",
+		explorers: 1,
+	}} {
+		id := caip.ChainID{}
+		id.ParseX(tc.id)
+
+		c, ok := Lookup(id)
+		if !ok {
+			t.Errorf("%s: chain not found", tc.id)
+			continue
+		}
+
+		if c.Name != tc.name || c.NativeCurrency.Symbol != tc.symbol || c.NativeCurrency.Decimals != tc.decimals {
+			t.Errorf("%s: chain invalid: %+v", tc.id, c)
+		}
+
+		if c.Testnet != tc.testnet {
+			t.Errorf("%s: testnet flag invalid", tc.id)
+		}
+
+		if len(c.Explorers) != tc.explorers {
+			t.Errorf("%s: explorers invalid: %+v", tc.id, c.Explorers)
+		}
+
+		native := ""
+		if c.NativeAsset != nil {
+			native = c.NativeAsset.String()
+		}
+		if native != tc.native {
+			t.Errorf("%s: native asset invalid: %s", tc.id, native)
+		}
+
+		parent := ""
+		if c.Parent != nil {
+			parent = c.Parent.String()
+		}
+		if parent != tc.parent {
+			t.Errorf("%s: parent invalid: %s", tc.id, parent)
+		}
+	}
+}
+
+func TestEmbeddedChains(t *testing.T) {
+	if err := NewRegistry().Load(bytes.NewReader(chainsData)); err != nil {
+		t.Errorf("Invalid embedded chains: %v", err)
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	data := `[
+		{"name": "Good", "chainId": 31337, "faucets": ["https://faucet.example"]},
+		{"name": "Bad id", "caip2": "EIP155:1"},
+		{"name": "Bad type", "chainId": "31338"},
+		{"name": "Missing id"},
+		{"name": "Test", "chainId": 31339, "slip44": 1}
+	]`
+
+	r := NewRegistry()
+	err := r.Load(strings.NewReader(data))
+
+	var lerr *LoadError
+	if !errors.As(err, &lerr) || len(lerr.Errs) != 3 {
+		t.Fatalf("expected 3 skipped chains, got: %v", err)
+	}
+
+	if !errors.Is(lerr.Errs[0], caip.ErrNamespaceInvalid) {
+		t.Errorf("expected namespace error, got: %v", lerr.Errs[0])
+	}
+
+	if c, ok := r.Lookup(caip.UnsafeChainID("eip155", "31337")); !ok || c.Testnet {
+		t.Errorf("Chain with faucets should be loaded as mainnet: %+v", c)
+	}
+
+	if c, ok := r.Lookup(caip.UnsafeChainID("eip155", "31339")); !ok || !c.Testnet {
+		t.Errorf("Chain with slip44 coin type 1 should be loaded as testnet: %+v", c)
+	}
+
+	if err := r.Load(strings.NewReader("{")); err == nil || errors.As(err, &lerr) {
+		t.Errorf("Invalid json should fail the load: %v", err)
+	}
+}
+
+func TestLookupAlias(t *testing.T) {
+	for alias, id := range map[string]string{
+		"ethereum": "eip155:1",
+		"Polygon":  "eip155:137",
+		"matic":    "eip155:137",
+		"arb1":     "eip155:42161",
+		"solana":   "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp",
+	} {
+		c, ok := LookupAlias(alias)
+		if !ok {
+			t.Errorf("%s: chain not found", alias)
+			continue
+		}
+
+		if c.ID.String() != id {
+			t.Errorf("%s: chain id invalid: %s", alias, c.ID)
+		}
+	}
+
+	if _, ok := LookupAlias("unknown"); ok {
+		t.Errorf("Unknown alias should not be found")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	data := `[{
+		"name": "Polygon PoS",
+		"shortName": "pol",
+		"chainId": 137,
+		"nativeCurrency": {"name": "POL", "symbol": "POL", "decimals": 18},
+		"aliases": ["polygon-pos"]
+	}, {
+		"name": "Local",
+		"shortName": "local",
+		"chainId": 31337,
+		"nativeCurrency": {"name": "Ether", "symbol": "ETH", "decimals": 18},
+		"testnet": true
+	}]`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry()
+	for _, c := range Default.Chains() {
+		r.Add(c)
+	}
+
+	if err := r.LoadFile(path); err != nil {
+		t.Fatalf("Failed to load chains: %v", err)
+	}
+
+	c, ok := r.Lookup(caip.UnsafeChainID("eip155", "137"))
+	if !ok || c.NativeCurrency.Symbol != "POL" {
+		t.Errorf("Chain not overridden: %+v", c)
+	}
+
+	if _, ok := r.LookupAlias("polygon"); ok {
+		t.Errorf("Aliases of overridden chain should be removed")
+	}
+
+	if c, ok := r.LookupAlias("polygon-pos"); !ok || c.ID.String() != "eip155:137" {
+		t.Errorf("Alias of overriding chain not found")
+	}
+
+	if c, ok := r.LookupAlias("local"); !ok || !c.Testnet {
+		t.Errorf("Loaded chain invalid: %+v", c)
+	}
+
+	if _, ok := Lookup(caip.UnsafeChainID("eip155", "31337")); ok {
+		t.Errorf("Default registry should not be modified")
+	}
+}
@@ -0,0 +1,259 @@
+// Command caip parses, validates, normalizes and explains CAIP-2 chain ids,
+// CAIP-10 account ids and CAIP-19 asset ids.
+//
+// Usage:
+//
+//	caip <command> [identifier ...]
+//
+// Identifiers are read from standard input, one per line, when none are given
+// as arguments. Errors are reported per input on standard error. The exit
+// status is 0 if all inputs are valid, 1 if any is invalid and 2 for usage or
+// read errors.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/ChainAgnostic/go-caip/chains"
+)
+
+const usage = `usage: caip <command> [identifier ...]
+
+Identifiers are read from stdin, one per line, when none are given.
+
+commands:
+  validate   report invalid identifiers
+  normalize  print identifiers in their canonical form
+  explain    print the components of identifiers
+  json       convert identifiers to JSON objects, and JSON back to identifiers
+`
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+// identifier is implemented by caip.ChainID, caip.AccountID and caip.AssetID.
+type identifier interface {
+	fmt.Stringer
+	Canonical() (string, error)
+	Validate() error
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	var command func(w io.Writer, input string) error
+	switch args[0] {
+	case "validate":
+		command = validate
+	case "normalize":
+		command = normalize
+	case "explain":
+		command = explain
+	case "json":
+		command = convertJSON
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "caip: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	status := exitOK
+	handle := func(position, input string) {
+		if err := command(stdout, input); err != nil {
+			fmt.Fprintf(stderr, "caip: %s: %v\n", position, err)
+			status = exitInvalid
+		}
+	}
+
+	if inputs := args[1:]; len(inputs) > 0 {
+		for i, input := range inputs {
+			handle(fmt.Sprintf("argument %d", i+1), input)
+		}
+		return status
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for line := 1; scanner.Scan(); line++ {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		handle(fmt.Sprintf("line %d", line), input)
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "caip: reading stdin: %v\n", err)
+		return exitUsage
+	}
+
+	return status
+}
+
+// parse parses s as an asset id if it has an asset part, and otherwise as a
+// chain id or account id depending on its number of components. The returned
+// identifier holds the parsed components even if it is invalid.
+func parse(s string) (identifier, error) {
+	switch {
+	case strings.Contains(s, "/"):
+		var a caip.AssetID
+		err := a.Parse(s)
+		return a, err
+	case strings.Count(s, ":") == 1:
+		var c caip.ChainID
+		err := c.Parse(s)
+		return c, err
+	default:
+		var a caip.AccountID
+		err := a.Parse(s)
+		return a, err
+	}
+}
+
+func validate(w io.Writer, input string) error {
+	_, err := parse(input)
+	return err
+}
+
+func normalize(w io.Writer, input string) error {
+	id, err := parse(input)
+	if err != nil {
+		return err
+	}
+
+	s, err := id.Canonical()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, s)
+	return nil
+}
+
+func explain(w io.Writer, input string) error {
+	id, err := parse(input)
+	if errors.Is(err, caip.ErrMalformed) {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, input)
+
+	var chainID caip.ChainID
+	switch id := id.(type) {
+	case caip.ChainID:
+		fmt.Fprintln(tw, "  kind\tchain id (CAIP-2)")
+		chainID = id
+	case caip.AccountID:
+		fmt.Fprintln(tw, "  kind\taccount id (CAIP-10)")
+		chainID = id.ChainID
+	case caip.AssetID:
+		fmt.Fprintln(tw, "  kind\tasset id (CAIP-19)")
+		chainID = id.ChainID
+	}
+
+	if c, ok := chains.Lookup(chainID); ok {
+		fmt.Fprintf(tw, "  chain\t%s\n", c.Name)
+	}
+	fmt.Fprintf(tw, "  namespace\t%s\n", chainID.Namespace)
+	fmt.Fprintf(tw, "  reference\t%s\n", chainID.Reference)
+
+	switch id := id.(type) {
+	case caip.AccountID:
+		fmt.Fprintf(tw, "  account_address\t%s\n", id.Address)
+	case caip.AssetID:
+		fmt.Fprintf(tw, "  asset_namespace\t%s\n", id.Namespace)
+		fmt.Fprintf(tw, "  asset_reference\t%s\n", id.Reference)
+		if id.TokenID != "" {
+			fmt.Fprintf(tw, "  token_id\t%s\n", id.TokenID)
+		}
+	}
+
+	if err == nil {
+		if s, err := id.Canonical(); err == nil && s != input {
+			fmt.Fprintf(tw, "  canonical\t%s\n", s)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Invalid identifiers are explained as far as they could be parsed
+	return err
+}
+
+// convertJSON prints JSON objects and strings as identifiers, and identifiers
+// as JSON objects.
+func convertJSON(w io.Writer, input string) error {
+	if strings.HasPrefix(input, "{") || strings.HasPrefix(input, `"`) {
+		id, err := unmarshalIdentifier([]byte(input))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(w, id)
+		return nil
+	}
+
+	id, err := parse(input)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, string(b))
+	return nil
+}
+
+// unmarshalIdentifier decodes a JSON object or string, choosing the identifier
+// type from the fields of the object.
+func unmarshalIdentifier(data []byte) (identifier, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return parse(s)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fields["asset_namespace"] != nil:
+		var a caip.AssetID
+		err := json.Unmarshal(data, &a)
+		return a, err
+	case fields["account_address"] != nil:
+		var a caip.AccountID
+		err := json.Unmarshal(data, &a)
+		return a, err
+	default:
+		var c caip.ChainID
+		err := json.Unmarshal(data, &c)
+		return c, err
+	}
+}
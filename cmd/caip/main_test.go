@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		args   []string
+		stdin  string
+		stdout string
+		stderr string
+		status int
+	}{{
+		name:   "usage",
+		stderr: usage,
+		status: exitUsage,
+	}, {
+		name:   "unknown command",
+		args:   []string{"frobnicate"},
+		stderr: "caip: unknown command \"frobnicate\"\n\n" + usage,
+		status: exitUsage,
+	}, {
+		name:   "validate",
+		args:   []string{"validate", "eip155:1", "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb"},
+		status: exitOK,
+	}, {
+		name:   "validate stdin",
+		args:   []string{"validate"},
+		stdin:  "eip155:1\n\nEIP155:1\r\neip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f\neip155\n",
+		stderr: "caip: line 3: invalid chain id namespace \"EIP155\" at offset 0: namespace does not match spec\ncaip: line 5: invalid account id \"eip155\" at offset 6: malformed identifier\n",
+		status: exitInvalid,
+	}, {
+		name:   "normalize",
+		args:   []string{"normalize", "eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb", "cosmos:cosmoshub-3:COSMOS1T2UFLQWQE0FSJ0SHCFKRVPUKEWCW40YJJ6HDC0"},
+		stdout: "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb\ncosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0\n",
+		status: exitOK,
+	}, {
+		name:   "json",
+		args:   []string{"json", "eip155:137", `{"chain_id":{"namespace":"eip155","reference":"1"},"asset_namespace":"slip44","asset_reference":"60"}`, `"eip155:1"`},
+		stdout: "{\"namespace\":\"eip155\",\"reference\":\"137\"}\neip155:1/slip44:60\neip155:1\n",
+		status: exitOK,
+	}, {
+		name:   "json invalid",
+		args:   []string{"json", `{"namespace":"eip155","reference":"x"}`},
+		stderr: "caip: argument 1: invalid chain id reference \"x\" at offset 7: reference does not match spec: invalid eip155 chain id: x\n",
+		status: exitInvalid,
+	}, {
+		name: "explain",
+		args: []string{"explain", "eip155:1/erc721:0x06012c8cf97bead5deae237070f9587f8e7a266d/771769"},
+		stdout: `eip155:1/erc721:0x06012c8cf97bead5deae237070f9587f8e7a266d/771769
+  kind             asset id (CAIP-19)
+  chain            Ethereum Mainnet
+  namespace        eip155
+  reference        1
+  asset_namespace  erc721
+  asset_reference  0x06012c8cf97bead5deae237070f9587f8e7a266d
+  token_id         771769
+  canonical        eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769
+`,
+		status: exitOK,
+	}, {
+		name: "explain invalid",
+		args: []string{"explain", "eip155:1:0xnope"},
+		stdout: `eip155:1:0xnope
+  kind             account id (CAIP-10)
+  chain            Ethereum Mainnet
+  namespace        eip155
+  reference        1
+  account_address  0xnope
+`,
+		stderr: "caip: argument 1: invalid account id account_address \"0xnope\" at offset 9: address does not match spec: invalid eth address: 0xnope\n",
+		status: exitInvalid,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+
+			if status != tc.status {
+				t.Errorf("expected status %d, got: %d", tc.status, status)
+			}
+
+			if stdout.String() != tc.stdout {
+				t.Errorf("expected stdout:\n%s\ngot:\n%s", tc.stdout, stdout.String())
+			}
+
+			if stderr.String() != tc.stderr {
+				t.Errorf("expected stderr:\n%s\ngot:\n%s", tc.stderr, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package caip
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestAccountIDEqual(t *testing.T) {
+	for _, tc := range []struct {
+		a, b      string
+		equal     bool
+		canonical string
+	}{{
+		a:         "eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb",
+		b:         "eip155:1:0xAB16A96D359EC26A11E2C2B3D8F8B8942D5BFCDB",
+		equal:     true,
+		canonical: "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+	}, {
+		a:         "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+		b:         "eip155:137:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+		canonical: "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+	}, {
+		a:         "cosmos:cosmoshub-3:COSMOS1T2UFLQWQE0FSJ0SHCFKRVPUKEWCW40YJJ6HDC0",
+		b:         "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		equal:     true,
+		canonical: "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+	}, {
+		// base58 addresses are case-sensitive
+		a:         "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+		b:         "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7s3p4hxjpyyiggzodywhtcxzyuqe9jibmhyrwxaraakv",
+		canonical: "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+	}} {
+		a, b := AccountID{}, AccountID{}
+		a.ParseX(tc.a)
+		b.ParseX(tc.b)
+
+		if a.Equal(b) != tc.equal || b.Equal(a) != tc.equal {
+			t.Errorf("%s == %s should be %t", tc.a, tc.b, tc.equal)
+		}
+
+		if (a.Compare(b) == 0) != tc.equal || a.Compare(b) != -b.Compare(a) {
+			t.Errorf("%s compared to %s invalid: %d", tc.a, tc.b, a.Compare(b))
+		}
+
+		c, err := a.Canonical()
+		if err != nil || c != tc.canonical {
+			t.Errorf("Canonical %s invalid: %s, %v", tc.a, c, err)
+		}
+
+		// Stored and marshalled values keep the given form
+		if v, err := a.Value(); err != nil || v != tc.a {
+			t.Errorf("Value %s invalid: %v, %v", tc.a, v, err)
+		}
+
+		if text, err := a.MarshalText(); err != nil || string(text) != tc.a {
+			t.Errorf("MarshalText %s invalid: %s, %v", tc.a, text, err)
+		}
+	}
+}
+
+func TestAssetIDEqual(t *testing.T) {
+	a, b := AssetID{}, AssetID{}
+	a.ParseX("eip155:1/erc721:0x06012c8cf97bead5deae237070f9587f8e7a266d/0771769")
+	b.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769")
+
+	if !a.Equal(b) || a.Compare(b) != 0 {
+		t.Errorf("%s should equal %s", a, b)
+	}
+
+	if c, _ := a.Canonical(); c != b.String() {
+		t.Errorf("Canonical asset id invalid: %s", c)
+	}
+
+	if !a.AssetType().Equal(b.AssetType()) {
+		t.Errorf("Asset types should be equal")
+	}
+
+	if c, _ := a.AssetType().Canonical(); c != b.AssetType().String() {
+		t.Errorf("Canonical asset type invalid: %s", c)
+	}
+
+	b.TokenID = "771770"
+	if a.Equal(b) || a.Compare(b) >= 0 {
+		t.Errorf("%s should be less than %s", a, b)
+	}
+
+	// Invalid asset ids are compared as they are
+	if !(AssetID{}).Equal(AssetID{}) || (AssetID{}).Equal(a) {
+		t.Errorf("Invalid asset id comparison invalid")
+	}
+}
+
+func TestCompareSort(t *testing.T) {
+	ids := []string{
+		"eip155:137:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+		"eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb",
+		"cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		"eip155:1:0xAB16A96D359EC26A11E2C2B3D8F8B8942D5BFCDB",
+		"eip155:1:0x0000000000000000000000000000000000000001",
+	}
+
+	accounts := make([]AccountID, len(ids))
+	for i, id := range ids {
+		accounts[i].ParseX(id)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Compare(accounts[j]) < 0
+	})
+
+	set := map[AccountID]bool{}
+	var sorted []string
+	for _, a := range accounts {
+		n, err := a.Normalize()
+		if err != nil {
+			t.Fatalf("Failed to normalize: %v", err)
+		}
+
+		if !set[n] {
+			set[n] = true
+			sorted = append(sorted, n.String())
+		}
+	}
+
+	expected := []string{
+		"cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		"eip155:1:0x0000000000000000000000000000000000000001",
+		"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+		"eip155:137:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+	}
+
+	if len(sorted) != len(expected) {
+		t.Fatalf("expected %v, got: %v", expected, sorted)
+	}
+
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("expected %v, got: %v", expected, sorted)
+			break
+		}
+	}
+
+	if (ChainID{"eip155", "1"}).Compare(ChainID{"eip155", "137"}) != -1 {
+		t.Errorf("Chain id comparison invalid")
+	}
+}
+
+func TestAmountEqualAssets(t *testing.T) {
+	a, b := AssetID{}, AssetID{}
+	a.ParseX("eip155:1/erc20:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	b.ParseX("eip155:1/erc20:0xA0b86991c6218b36c1D19D4a2e9Eb0cE3606eB48")
+
+	x, _ := ParseAmount(a, "1", 6)
+	y, _ := ParseAmount(b, "2", 6)
+	if sum, err := x.Add(y); err != nil || sum.FormatDecimal() != "3" {
+		t.Errorf("Adding amounts of the same asset failed: %s, %v", sum, err)
+	}
+}
@@ -0,0 +1,189 @@
+package caip
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type cosmosNamespace struct{}
+
+var (
+	cosmosChainIDRegex = regexp.MustCompile("^[-a-zA-Z0-9]{1,32}$")
+	cosmosHashedRegex  = regexp.MustCompile("^hashed-[0-9a-f]{16}$")
+	cosmosPrefixesMu   sync.RWMutex
+	cosmosPrefixes     = map[string]string{
+		"cosmoshub-2":          "cosmos",
+		"cosmoshub-3":          "cosmos",
+		"cosmoshub-4":          "cosmos",
+		"osmosis-1":            "osmo",
+		"juno-1":               "juno",
+		"akashnet-2":           "akash",
+		"stargaze-1":           "stars",
+		"secret-4":             "secret",
+		"celestia":             "celestia",
+		"dydx-mainnet-1":       "dydx",
+		"noble-1":              "noble",
+		"injective-1":          "inj",
+		"Binance-Chain-Tigris": "bnb",
+		"iov-mainnet":          "star",
+	}
+)
+
+func init() {
+	RegisterNamespace("cosmos", cosmosNamespace{})
+}
+
+// RegisterCosmosPrefix sets the bech32 prefix account addresses on the chain
+// with the given reference must use.
+func RegisterCosmosPrefix(reference, prefix string) {
+	cosmosPrefixesMu.Lock()
+	defer cosmosPrefixesMu.Unlock()
+	cosmosPrefixes[reference] = prefix
+}
+
+func CosmosPrefix(reference string) (string, bool) {
+	cosmosPrefixesMu.RLock()
+	defer cosmosPrefixesMu.RUnlock()
+	prefix, ok := cosmosPrefixes[reference]
+	return prefix, ok
+}
+
+// CosmosChainID returns the CAIP-2 chain id for a Cosmos chain id, chain ids
+// that do not fit into a chain reference are hashed.
+func CosmosChainID(chainID string) ChainID {
+	if cosmosChainIDRegex.MatchString(chainID) {
+		return ChainID{"cosmos", chainID}
+	}
+
+	sum := sha256.Sum256([]byte(chainID))
+	return ChainID{"cosmos", "hashed-" + hex.EncodeToString(sum[:])[:16]}
+}
+
+// ValidateReference accepts Cosmos chain ids that CosmosChainID keeps as they
+// are and the hashed form of all others.
+func (cosmosNamespace) ValidateReference(reference string) error {
+	if strings.HasPrefix(reference, "hashed-") {
+		if !cosmosHashedRegex.MatchString(reference) {
+			return fmt.Errorf("%w: invalid hashed cosmos chain id: %s", ErrReferenceInvalid, reference)
+		}
+		return nil
+	}
+
+	if !cosmosChainIDRegex.MatchString(reference) {
+		return fmt.Errorf("%w: cosmos chain id must be hashed: %s", ErrReferenceInvalid, reference)
+	}
+
+	return nil
+}
+
+func (cosmosNamespace) ValidateAddress(chainID ChainID, address string) error {
+	if _, _, err := cosmosAddress(chainID, address); err != nil {
+		return fmt.Errorf("%w: %s", ErrAddressInvalid, err)
+	}
+
+	return nil
+}
+
+func (n cosmosNamespace) NormalizeAddress(chainID ChainID, address string) (string, error) {
+	if err := n.ValidateAddress(chainID, address); err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(address), nil
+}
+
+func (cosmosNamespace) ValidateAsset(a AssetID) error {
+	return nil
+}
+
+func cosmosAddress(chainID ChainID, address string) (string, []byte, error) {
+	prefix, data, variant, err := bech32Decode(address)
+	if err != nil || variant != bech32 {
+		return "", nil, fmt.Errorf("invalid cosmos address: %s", address)
+	}
+
+	if expected, ok := CosmosPrefix(chainID.Reference); ok && prefix != expected {
+		return "", nil, fmt.Errorf("invalid cosmos address prefix: %s", prefix)
+	}
+
+	b, err := bech32ConvertBits(data, 5, 8, false)
+	if err != nil || (len(b) != 20 && len(b) != 32) {
+		return "", nil, fmt.Errorf("invalid cosmos address: %s", address)
+	}
+
+	return prefix, b, nil
+}
+
+type CosmosAccountID struct {
+	AccountID
+}
+
+func NewCosmosAccountID(chainID ChainID, address string) (CosmosAccountID, error) {
+	aID := CosmosAccountID{AccountID{chainID, address}}
+	if err := aID.Validate(); err != nil {
+		return CosmosAccountID{}, err
+	}
+
+	aID.AccountID.Address = strings.ToLower(aID.AccountID.Address)
+	return aID, nil
+}
+
+func UnsafeCosmosAccountID(chainID ChainID, address string) CosmosAccountID {
+	return CosmosAccountID{AccountID{chainID, strings.ToLower(address)}}
+}
+
+func (a CosmosAccountID) Validate() error {
+	if _, _, err := cosmosAddress(a.ChainID, a.Address); err != nil {
+		return err
+	}
+
+	if a.ChainID.Namespace != "cosmos" {
+		return fmt.Errorf("invalid chain namespace: %s", a.ChainID.Namespace)
+	}
+
+	return a.AccountID.Validate()
+}
+
+func (a *CosmosAccountID) Parse(s string) error {
+	return parseTyped(s, a.AccountID.Parse, a)
+}
+
+func (a *CosmosAccountID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *CosmosAccountID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *CosmosAccountID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AccountID.UnmarshalJSON, a)
+}
+
+func (a *CosmosAccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *CosmosAccountID) Scan(src interface{}) error {
+	return scanParse(AccountIDKind, src, a.Parse)
+}
+
+func (a *CosmosAccountID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AccountIDKind, v, a.Parse)
+}
+
+func (a CosmosAccountID) Prefix() string {
+	prefix, _, _ := cosmosAddress(a.ChainID, a.Address)
+	return prefix
+}
+
+func (a CosmosAccountID) Bytes() []byte {
+	_, b, _ := cosmosAddress(a.ChainID, a.Address)
+	return b
+}
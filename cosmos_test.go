@@ -0,0 +1,148 @@
+package caip
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCosmosAccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id     string
+		prefix string
+	}{{
+		// Cosmos Hub
+		id:     "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		prefix: "cosmos",
+	}, {
+		// Osmosis
+		id:     "cosmos:osmosis-1:osmo1t2uflqwqe0fsj0shcfkrvpukewcw40yj6pyawa",
+		prefix: "osmo",
+	}, {
+		// Unknown chain, any prefix
+		id:     "cosmos:testnet-1:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		prefix: "cosmos",
+	}} {
+		a := CosmosAccountID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse account id: %v", err)
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		if _, err := NewCosmosAccountID(a.ChainID, a.Address); err != nil {
+			t.Errorf("Failed to create account id from address")
+		}
+
+		if a.Prefix() != tc.prefix {
+			t.Errorf("Prefix invalid: %s", a.Prefix())
+		}
+
+		if hex.EncodeToString(a.Bytes()) != "5ab89f81c0cbd3093e17c26c360796cbb0eabc92" {
+			t.Errorf("Address bytes invalid: %x", a.Bytes())
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = CosmosAccountID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled account id invalid")
+		}
+	}
+}
+
+func TestInvalidCosmosAccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		err error
+	}{{
+		// Bad checksum
+		id:  "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc1",
+		err: fmt.Errorf("invalid cosmos address: %s", "cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc1"),
+	}, {
+		// bech32m checksum
+		id:  "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yj8x8pad",
+		err: fmt.Errorf("invalid cosmos address: %s", "cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yj8x8pad"),
+	}, {
+		// Osmosis address on the Cosmos Hub
+		id:  "cosmos:cosmoshub-3:osmo1t2uflqwqe0fsj0shcfkrvpukewcw40yj6pyawa",
+		err: fmt.Errorf("invalid cosmos address prefix: %s", "osmo"),
+	}, {
+		id:  "bip122:000000000019d6689c085ae165831e93:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		err: fmt.Errorf("invalid chain namespace: %s", "bip122"),
+	}} {
+		a := CosmosAccountID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse account id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		_, err := NewCosmosAccountID(a.ChainID, a.Address)
+		if err == nil {
+			t.Fatalf("Create account id should error")
+		}
+
+		if err.Error() != tc.err.Error() {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
+
+func TestCosmosChainID(t *testing.T) {
+	for _, tc := range []struct {
+		chainID string
+		id      string
+	}{{
+		chainID: "cosmoshub-4",
+		id:      "cosmos:cosmoshub-4",
+	}, {
+		chainID: "evmos_9001-2",
+		id:      "cosmos:hashed-95094315a9d6cf2a",
+	}, {
+		chainID: "a-very-long-cosmos-chain-identifier-1",
+		id:      "cosmos:hashed-0aa30f1770511b53",
+	}} {
+		c := CosmosChainID(tc.chainID)
+		if c.String() != tc.id {
+			t.Errorf("%s: chain id invalid: %s", tc.chainID, c)
+		}
+
+		if err := c.Validate(); err != nil {
+			t.Errorf("%s: chain id should be valid: %v", tc.chainID, err)
+		}
+	}
+
+	// Chain ids that CosmosChainID hashes are only accepted hashed
+	for _, id := range []string{"cosmos:hashed-xyz", "cosmos:evmos_9001-2"} {
+		if err := new(ChainID).Parse(id); !errors.Is(err, ErrReferenceInvalid) {
+			t.Errorf("%s: expected reference error, got: %v", id, err)
+		}
+	}
+}
+
+func TestRegisterCosmosPrefix(t *testing.T) {
+	RegisterCosmosPrefix("testnet-1", "test")
+	defer func() {
+		cosmosPrefixesMu.Lock()
+		delete(cosmosPrefixes, "testnet-1")
+		cosmosPrefixesMu.Unlock()
+	}()
+
+	if err := new(AccountID).Parse("cosmos:testnet-1:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0"); !errors.Is(err, ErrAddressInvalid) {
+		t.Errorf("expected address error, got: %v", err)
+	}
+}
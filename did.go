@@ -0,0 +1,123 @@
+package caip
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// DIDPKHPrefix is the prefix of did:pkh DIDs, which are followed by a CAIP-10
+// account id.
+const DIDPKHPrefix = "did:pkh:"
+
+// Verification method types used in did:pkh documents.
+const (
+	EcdsaSecp256k1RecoveryMethod2020 = "EcdsaSecp256k1RecoveryMethod2020"
+	Ed25519VerificationKey2018       = "Ed25519VerificationKey2018"
+	BlockchainVerificationMethod2021 = "BlockchainVerificationMethod2021"
+)
+
+const didContext = "https://www.w3.org/ns/did/v1"
+
+// didTerms are the JSON-LD definitions of the terms used by verification
+// methods.
+var didTerms = map[string]interface{}{
+	"blockchainAccountId":            "https://w3id.org/security#blockchainAccountId",
+	EcdsaSecp256k1RecoveryMethod2020: "https://identity.foundation/EcdsaSecp256k1RecoverySignature2020#EcdsaSecp256k1RecoveryMethod2020",
+	Ed25519VerificationKey2018:       "https://w3id.org/security#Ed25519VerificationKey2018",
+	BlockchainVerificationMethod2021: "https://w3id.org/security#BlockchainVerificationMethod2021",
+	"publicKeyJwk": map[string]interface{}{
+		"@id":   "https://w3id.org/security#publicKeyJwk",
+		"@type": "@json",
+	},
+}
+
+// DID returns the did:pkh DID of the account id.
+func (c AccountID) DID() string {
+	return DIDPKHPrefix + c.String()
+}
+
+// ParseDIDPKH parses a did:pkh DID into its account id. DID URLs with a path,
+// query or fragment are rejected.
+func ParseDIDPKH(did string) (AccountID, error) {
+	if !strings.HasPrefix(did, DIDPKHPrefix) {
+		return AccountID{}, malformedError(AccountIDKind, did)
+	}
+
+	var aID AccountID
+	if err := aID.Parse(did[len(DIDPKHPrefix):]); err != nil {
+		return AccountID{}, err
+	}
+
+	return aID, nil
+}
+
+type DIDDocument struct {
+	Context            []interface{}        `json:"@context"`
+	ID                 string               `json:"id"`
+	VerificationMethod []VerificationMethod `json:"verificationMethod"`
+	Authentication     []string             `json:"authentication"`
+	AssertionMethod    []string             `json:"assertionMethod"`
+}
+
+type VerificationMethod struct {
+	ID                  string `json:"id"`
+	Type                string `json:"type"`
+	Controller          string `json:"controller"`
+	BlockchainAccountID string `json:"blockchainAccountId"`
+	PublicKeyJWK        *JWK   `json:"publicKeyJwk,omitempty"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+// DIDDocument returns the did:pkh DID document of a valid account id. eip155
+// and bip122 accounts are verified by recovering secp256k1 signatures, solana
+// accounts by their Ed25519 public key and other namespaces by the generic
+// blockchain verification method.
+func (c AccountID) DIDDocument() (DIDDocument, error) {
+	if err := c.Validate(); err != nil {
+		return DIDDocument{}, err
+	}
+
+	did := c.DID()
+	vm := VerificationMethod{
+		ID:                  did + "#blockchainAccountId",
+		Controller:          did,
+		BlockchainAccountID: c.String(),
+	}
+
+	switch c.ChainID.Namespace {
+	case "eip155", "bip122":
+		vm.Type = EcdsaSecp256k1RecoveryMethod2020
+	case "solana":
+		key, err := solanaPublicKey(c.Address)
+		if err != nil {
+			return DIDDocument{}, err
+		}
+
+		vm.ID = did + "#controller"
+		vm.Type = Ed25519VerificationKey2018
+		vm.PublicKeyJWK = &JWK{"OKP", "Ed25519", base64.RawURLEncoding.EncodeToString(key)}
+	default:
+		vm.Type = BlockchainVerificationMethod2021
+	}
+
+	terms := map[string]interface{}{
+		"blockchainAccountId": didTerms["blockchainAccountId"],
+		vm.Type:               didTerms[vm.Type],
+	}
+	if vm.PublicKeyJWK != nil {
+		terms["publicKeyJwk"] = didTerms["publicKeyJwk"]
+	}
+
+	return DIDDocument{
+		Context:            []interface{}{didContext, terms},
+		ID:                 did,
+		VerificationMethod: []VerificationMethod{vm},
+		Authentication:     []string{vm.ID},
+		AssertionMethod:    []string{vm.ID},
+	}, nil
+}
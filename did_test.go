@@ -0,0 +1,114 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDIDPKH(t *testing.T) {
+	for _, id := range []string{
+		"eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a",
+		"solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:CKg5d12Jhpej1JqtmxLJgaFqqeYjxgPqToJ4LBdvG9Ev",
+		"bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6",
+	} {
+		aID := AccountID{}
+		aID.ParseX(id)
+
+		did := aID.DID()
+		if did != "did:pkh:"+id {
+			t.Errorf("DID invalid: %s", did)
+		}
+
+		aID2, err := ParseDIDPKH(did)
+		if err != nil {
+			t.Fatalf("Failed to parse did: %v", err)
+		}
+
+		if aID2 != aID {
+			t.Errorf("Parsed account id invalid: %s", aID2)
+		}
+	}
+
+	for _, tc := range []struct {
+		did string
+		err error
+	}{
+		{"did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK", ErrMalformed},
+		{"did:pkh:eip155:1", ErrMalformed},
+		{"did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a#blockchainAccountId", ErrAddressInvalid},
+		{"eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a", ErrMalformed},
+	} {
+		if _, err := ParseDIDPKH(tc.did); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got: %v", tc.did, tc.err, err)
+		}
+	}
+}
+
+func TestDIDDocument(t *testing.T) {
+	aID := AccountID{}
+	aID.ParseX("eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a")
+
+	doc, err := aID.DIDDocument()
+	if err != nil {
+		t.Fatalf("Failed to create did document: %v", err)
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	expected := `{"@context":["https://www.w3.org/ns/did/v1",{"EcdsaSecp256k1RecoveryMethod2020":"https://identity.foundation/EcdsaSecp256k1RecoverySignature2020#EcdsaSecp256k1RecoveryMethod2020","blockchainAccountId":"https://w3id.org/security#blockchainAccountId"}],` +
+		`"id":"did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a",` +
+		`"verificationMethod":[{"id":"did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a#blockchainAccountId","type":"EcdsaSecp256k1RecoveryMethod2020","controller":"did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a","blockchainAccountId":"eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a"}],` +
+		`"authentication":["did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a#blockchainAccountId"],` +
+		`"assertionMethod":["did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a#blockchainAccountId"]}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got: %s", expected, b)
+	}
+
+	for _, tc := range []struct {
+		id     string
+		vmType string
+		vmID   string
+		x      string
+	}{{
+		id:     "solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:CKg5d12Jhpej1JqtmxLJgaFqqeYjxgPqToJ4LBdvG9Ev",
+		vmType: Ed25519VerificationKey2018,
+		vmID:   "did:pkh:solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:CKg5d12Jhpej1JqtmxLJgaFqqeYjxgPqToJ4LBdvG9Ev#controller",
+		x:      "qDkywhH-S6nNxQhA6SHKsoFW7A2gX-X0b3TtwVBMHm8",
+	}, {
+		id:     "bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6",
+		vmType: EcdsaSecp256k1RecoveryMethod2020,
+		vmID:   "did:pkh:bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6#blockchainAccountId",
+	}, {
+		id:     "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		vmType: BlockchainVerificationMethod2021,
+		vmID:   "did:pkh:cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0#blockchainAccountId",
+	}} {
+		aID.ParseX(tc.id)
+		doc, err := aID.DIDDocument()
+		if err != nil {
+			t.Fatalf("Failed to create did document: %v", err)
+		}
+
+		vm := doc.VerificationMethod[0]
+		if vm.Type != tc.vmType || vm.ID != tc.vmID || vm.BlockchainAccountID != tc.id || vm.Controller != aID.DID() {
+			t.Errorf("%s: verification method invalid: %+v", tc.id, vm)
+		}
+
+		if tc.x != "" && (vm.PublicKeyJWK == nil || vm.PublicKeyJWK.X != tc.x) {
+			t.Errorf("%s: public key invalid: %+v", tc.id, vm.PublicKeyJWK)
+		}
+
+		terms := doc.Context[1].(map[string]interface{})
+		if terms[vm.Type] == nil || (tc.x != "") != (terms["publicKeyJwk"] != nil) {
+			t.Errorf("%s: context invalid: %v", tc.id, terms)
+		}
+	}
+
+	if _, err := UnsafeAccountID(ChainID{"eip155", "1"}, "0xnope").DIDDocument(); !errors.Is(err, ErrAddressInvalid) {
+		t.Errorf("expected invalid address, got: %v", err)
+	}
+}
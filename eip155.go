@@ -0,0 +1,114 @@
+package caip
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type eip155Namespace struct{}
+
+var (
+	eip155ReferenceChars = charClass("0123456789")
+)
+
+func init() {
+	RegisterNamespace("eip155", eip155Namespace{})
+}
+
+func (eip155Namespace) ValidateReference(reference string) error {
+	if ok := match(reference, eip155ReferenceChars, 1, 32); !ok {
+		return fmt.Errorf("%w: invalid eip155 chain id: %s", ErrReferenceInvalid, reference)
+	}
+
+	return nil
+}
+
+func (eip155Namespace) ValidateAddress(chainID ChainID, address string) error {
+	if ok := common.IsHexAddress(address); !ok {
+		return fmt.Errorf("%w: invalid eth address: %s", ErrAddressInvalid, address)
+	}
+
+	return nil
+}
+
+func (n eip155Namespace) NormalizeAddress(chainID ChainID, address string) (string, error) {
+	if err := n.ValidateAddress(chainID, address); err != nil {
+		return "", err
+	}
+
+	return common.HexToAddress(address).Hex(), nil
+}
+
+func (eip155Namespace) ValidateAsset(a AssetID) error {
+	switch a.Namespace {
+	case "erc20", "erc721", "erc1155":
+	default:
+		return nil
+	}
+
+	if ok := common.IsHexAddress(a.Reference); !ok {
+		return fmt.Errorf("%w: invalid eth address: %s", ErrReferenceInvalid, a.Reference)
+	}
+
+	if a.TokenID != "" {
+		if a.Namespace == "erc20" {
+			return fmt.Errorf("%w: unexpected token id: %s", ErrTokenIDInvalid, a.TokenID)
+		}
+
+		if ok := validUint256(a.TokenID); !ok {
+			return fmt.Errorf("%w: invalid token id: %s", ErrTokenIDInvalid, a.TokenID)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeAsset checksums token contract addresses and strips leading zeros
+// from token ids.
+func (eip155Namespace) NormalizeAsset(a AssetID) (AssetID, error) {
+	switch a.Namespace {
+	case "erc20", "erc721", "erc1155":
+	default:
+		return a, nil
+	}
+
+	a.Reference = common.HexToAddress(a.Reference).Hex()
+	if i, ok := parseUint256(a.TokenID); ok {
+		a.TokenID = i.String()
+	}
+
+	return a, nil
+}
+
+// maxUint256 is 2^256-1 in decimal.
+const maxUint256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+// validUint256 reports whether s is a decimal EVM token id, without
+// allocating.
+func validUint256(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	s = strings.TrimLeft(s, "0")
+	return len(s) < len(maxUint256) || len(s) == len(maxUint256) && s <= maxUint256
+}
+
+// parseUint256 parses a decimal EVM token id.
+func parseUint256(s string) (*big.Int, bool) {
+	if !validUint256(s) {
+		return nil, false
+	}
+
+	i, ok := new(big.Int).SetString(s, 10)
+	return i, ok
+}
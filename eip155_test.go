@@ -0,0 +1,80 @@
+package caip
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEIP155Namespace(t *testing.T) {
+	for _, tc := range []struct {
+		id    string
+		parse func(string) error
+		err   error
+	}{{
+		id:    "eip155:137",
+		parse: new(ChainID).Parse,
+	}, {
+		id:    "eip155:mainnet",
+		parse: new(ChainID).Parse,
+		err:   ErrReferenceInvalid,
+	}, {
+		id:    "eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb",
+		parse: new(AccountID).Parse,
+	}, {
+		id:    "eip155:1:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		parse: new(AccountID).Parse,
+		err:   ErrAddressInvalid,
+	}, {
+		id:    "eip155:1/slip44:60",
+		parse: new(AssetID).Parse,
+	}, {
+		id:    "eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f/1",
+		parse: new(AssetID).Parse,
+		err:   ErrTokenIDInvalid,
+	}, {
+		id:    "eip155:1/erc1155:0x6b175474e89094c44da98b954eedeac495271d0f/1",
+		parse: new(AssetID).Parse,
+	}} {
+		if err := tc.parse(tc.id); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected error %v, got %v", tc.id, tc.err, err)
+		}
+	}
+}
+
+func TestEIP155NormalizeAddress(t *testing.T) {
+	a := AccountID{}
+	if err := a.Parse("eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb"); err != nil {
+		t.Fatalf("Failed to parse account id: %v", err)
+	}
+
+	n, err := a.Normalize()
+	if err != nil {
+		t.Fatalf("Failed to normalize account id: %v", err)
+	}
+
+	if n.Address != "0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb" {
+		t.Errorf("Normalized address not checksummed: %s", n.Address)
+	}
+}
+
+func TestValidUint256(t *testing.T) {
+	for _, tc := range []struct {
+		s  string
+		ok bool
+	}{
+		{"0", true},
+		{"000771769", true},
+		{maxUint256, true},
+		{"0" + maxUint256, true},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639936", false},
+		{"1" + maxUint256, false},
+		{"", false},
+		{"-0", false},
+		{"1.0", false},
+		{"0x1", false},
+	} {
+		if validUint256(tc.s) != tc.ok {
+			t.Errorf("%q: expected %t", tc.s, tc.ok)
+		}
+	}
+}
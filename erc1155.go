@@ -0,0 +1,89 @@
+package caip
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ERC1155AssetID struct {
+	EVMAssetID
+}
+
+func NewERC1155AssetID(chainID ChainID, namespace, reference string) (ERC1155AssetID, error) {
+	aID := ERC1155AssetID{EVMAssetID{AssetID: UnsafeAssetID(chainID, namespace, reference)}}
+	if err := aID.Validate(); err != nil {
+		return ERC1155AssetID{}, err
+	}
+
+	return aID, nil
+}
+
+func UnsafeERC1155AssetID(chainID ChainID, namespace, reference string) ERC1155AssetID {
+	aID := UnsafeAssetID(chainID, namespace, reference)
+	return ERC1155AssetID{EVMAssetID{AssetID: aID}}
+}
+
+func ERC1155AssetIDFromAssetID(aID AssetID) (ERC1155AssetID, error) {
+	a := ERC1155AssetID{EVMAssetID{AssetID: aID}}
+	if err := a.Validate(); err != nil {
+		return ERC1155AssetID{}, err
+	}
+
+	return a, nil
+}
+
+func (a ERC1155AssetID) Validate() error {
+	if ok := common.IsHexAddress(a.Reference); !ok {
+		return fmt.Errorf("%w: invalid eth address: %s", ErrReferenceInvalid, a.Reference)
+	}
+
+	if a.AssetID.Namespace != "erc1155" {
+		return fmt.Errorf("%w: invalid asset namespace: %s", ErrNamespaceInvalid, a.AssetID.Namespace)
+	}
+
+	if a.TokenID != "" {
+		if ok := validUint256(a.TokenID); !ok {
+			return fmt.Errorf("%w: invalid token id: %s", ErrTokenIDInvalid, a.TokenID)
+		}
+	}
+
+	return a.EVMAssetID.Validate()
+}
+
+func (a *ERC1155AssetID) Parse(s string) error {
+	return parseTyped(s, a.AssetID.Parse, a)
+}
+
+func (a *ERC1155AssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *ERC1155AssetID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *ERC1155AssetID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AssetID.UnmarshalJSON, a)
+}
+
+func (a *ERC1155AssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *ERC1155AssetID) Scan(src interface{}) error {
+	return scanParse(AssetIDKind, src, a.Parse)
+}
+
+func (a *ERC1155AssetID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
+}
+
+// Token returns the token id, or nil for an asset id of the whole contract.
+func (a ERC1155AssetID) Token() *big.Int {
+	i, _ := parseUint256(a.TokenID)
+	return i
+}
@@ -0,0 +1,141 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestERC1155AssetID(t *testing.T) {
+	for _, tc := range []struct {
+		id string
+	}{{
+		// OpenSea Shared Storefront
+		id: "eip155:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5e",
+	}, {
+		// OpenSea Shared Storefront token
+		id: "eip155:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5e/46180566860911566891640617163281087466447656640574620151209006585574719537153",
+	}} {
+		a := ERC1155AssetID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse asset id")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize asset id to string")
+		}
+
+		if _, err := NewERC1155AssetID(a.ChainID, a.AssetID.Namespace, joinTokenID(a.AssetID.Reference, a.TokenID)); err != nil {
+			t.Errorf("Failed to create asset id from address")
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = ERC1155AssetID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled asset id invalid")
+		}
+
+		a2 := ERC1155AssetID{}
+		if err := a2.Scan(a.String()); err != nil {
+			t.Errorf("Scanning value from sql.NullString")
+		}
+
+		if a2.String() != a.String() {
+			t.Errorf("Scanned value not valid")
+		}
+	}
+}
+
+func TestInvalidERC1155AssetID(t *testing.T) {
+	for _, tc := range []struct {
+		id       string
+		err      string
+		sentinel error
+	}{{
+		id:       "eip155:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5x",
+		err:      "reference does not match spec: invalid eth address: 0x495f947276749Ce646f68AC8c248420045cb7b5x",
+		sentinel: ErrReferenceInvalid,
+	}, {
+		id:       "eip155:1/erc20:0x495f947276749Ce646f68AC8c248420045cb7b5a",
+		err:      "namespace does not match spec: invalid asset namespace: erc20",
+		sentinel: ErrNamespaceInvalid,
+	}, {
+		id:       "eip155:1/erc1155:0x495f947276749Ce646f",
+		err:      "reference does not match spec: invalid eth address: 0x495f947276749Ce646f",
+		sentinel: ErrReferenceInvalid,
+	}, {
+		id:  "cosmos:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5e",
+		err: "invalid chain namespace: cosmos",
+	}, {
+		id:       "eip155:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5e/cat",
+		err:      "token id does not match spec: invalid token id: cat",
+		sentinel: ErrTokenIDInvalid,
+	}, {
+		// 2^256
+		id:       "eip155:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5e/115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		err:      "token id does not match spec: invalid token id: 115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		sentinel: ErrTokenIDInvalid,
+	}} {
+		a := ERC1155AssetID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse asset id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize asset id to string")
+		}
+
+		err := a.Validate()
+		if err == nil {
+			t.Errorf("Validate asset id should error")
+		}
+
+		if tc.sentinel != nil && !errors.Is(err, tc.sentinel) {
+			t.Errorf("expected error: %v, got: %v", tc.sentinel, err)
+		}
+
+		_, err = NewERC1155AssetID(a.ChainID, a.AssetID.Namespace, joinTokenID(a.AssetID.Reference, a.TokenID))
+		if err == nil {
+			t.Errorf("Create asset id should error")
+		}
+
+		if err.Error() != tc.err {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
+
+func TestERC1155AssetIDFromAssetID(t *testing.T) {
+	aID := AssetID{}
+	aID.ParseX("eip155:1/erc1155:0x495f947276749Ce646f68AC8c248420045cb7b5e/46180566860911566891640617163281087466447656640574620151209006585574719537153")
+
+	a, err := ERC1155AssetIDFromAssetID(aID)
+	if err != nil {
+		t.Fatalf("Failed to convert asset id: %v", err)
+	}
+
+	if a.TokenID != "46180566860911566891640617163281087466447656640574620151209006585574719537153" || a.String() != aID.String() {
+		t.Errorf("Converted asset id invalid: %s", a)
+	}
+
+	if a.Token().String() != a.TokenID {
+		t.Errorf("Token id invalid: %s", a.Token())
+	}
+
+	if a.AssetID != aID {
+		t.Errorf("Generic asset id invalid: %+v", a.AssetID)
+	}
+
+	aID.ParseX("eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f")
+	if _, err := ERC1155AssetIDFromAssetID(aID); err == nil {
+		t.Errorf("Converting erc20 asset id should error")
+	}
+}
@@ -9,7 +9,7 @@ type ERC20AssetID struct {
 }
 
 func NewERC20AssetID(chainID ChainID, namespace, reference string) (ERC20AssetID, error) {
-	aID := ERC20AssetID{EVMAssetID{AssetID: AssetID{chainID, namespace, reference}}}
+	aID := ERC20AssetID{EVMAssetID{AssetID: UnsafeAssetID(chainID, namespace, reference)}}
 	if err := aID.Validate(); err != nil {
 		return ERC20AssetID{}, err
 	}
@@ -18,7 +18,7 @@ func NewERC20AssetID(chainID ChainID, namespace, reference string) (ERC20AssetID
 }
 
 func UnsafeERC20AssetID(chainID ChainID, namespace, reference string) ERC20AssetID {
-	aID := AssetID{chainID, namespace, reference}
+	aID := UnsafeAssetID(chainID, namespace, reference)
 	return ERC20AssetID{EVMAssetID{AssetID: aID}}
 }
 
@@ -33,3 +33,33 @@ func (a ERC20AssetID) Validate() error {
 
 	return nil
 }
+
+func (a *ERC20AssetID) Parse(s string) error {
+	return parseTyped(s, a.AssetID.Parse, a)
+}
+
+func (a *ERC20AssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *ERC20AssetID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *ERC20AssetID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AssetID.UnmarshalJSON, a)
+}
+
+func (a *ERC20AssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *ERC20AssetID) Scan(src interface{}) error {
+	return scanParse(AssetIDKind, src, a.Parse)
+}
+
+func (a *ERC20AssetID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
+}
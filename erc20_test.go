@@ -70,8 +70,8 @@ func TestInvalidERC20AssetID(t *testing.T) {
 		err: fmt.Errorf("invalid chain namespace: %s", "cosmos"),
 	}} {
 		a := ERC20AssetID{}
-		if err := a.Parse(tc.id); err != nil {
-			t.Errorf("Failed to parse asset id")
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse asset id should error")
 		}
 
 		if a.String() != tc.id {
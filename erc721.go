@@ -3,7 +3,6 @@ package caip
 import (
 	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -13,7 +12,7 @@ type ERC721AssetID struct {
 }
 
 func NewERC721AssetID(chainID ChainID, namespace, reference string) (ERC721AssetID, error) {
-	aID := ERC721AssetID{EVMAssetID{AssetID: AssetID{chainID, namespace, reference}}}
+	aID := ERC721AssetID{EVMAssetID{AssetID: UnsafeAssetID(chainID, namespace, reference)}}
 	if err := aID.Validate(); err != nil {
 		return ERC721AssetID{}, err
 	}
@@ -22,34 +21,69 @@ func NewERC721AssetID(chainID ChainID, namespace, reference string) (ERC721Asset
 }
 
 func UnsafeERC721AssetID(chainID ChainID, namespace, reference string) ERC721AssetID {
-	aID := AssetID{chainID, namespace, reference}
+	aID := UnsafeAssetID(chainID, namespace, reference)
 	return ERC721AssetID{EVMAssetID{AssetID: aID}}
 }
 
-func (a ERC721AssetID) Validate() error {
-	if err := a.EVMAssetID.Validate(); err != nil {
-		return err
+func ERC721AssetIDFromAssetID(aID AssetID) (ERC721AssetID, error) {
+	a := ERC721AssetID{EVMAssetID{AssetID: aID}}
+	if err := a.Validate(); err != nil {
+		return ERC721AssetID{}, err
 	}
 
-	split := strings.Split(a.Reference, "/")
-	if ok := common.IsHexAddress(split[0]); !ok {
-		return fmt.Errorf("invalid eth address: %s", split[0])
+	return a, nil
+}
+
+func (a ERC721AssetID) Validate() error {
+	if ok := common.IsHexAddress(a.Reference); !ok {
+		return fmt.Errorf("invalid eth address: %s", a.Reference)
 	}
 
 	if a.AssetID.Namespace != "erc721" {
 		return fmt.Errorf("invalid asset namespace: %s", a.AssetID.Namespace)
 	}
 
-	if len(split) > 1 {
-		if _, ok := new(big.Int).SetString(split[1], 10); !ok {
-			return fmt.Errorf("invalid token id: %s", split[1])
+	if a.TokenID != "" {
+		if ok := validUint256(a.TokenID); !ok {
+			return fmt.Errorf("invalid token id: %s", a.TokenID)
 		}
 	}
 
-	return nil
+	return a.EVMAssetID.Validate()
+}
+
+func (a *ERC721AssetID) Parse(s string) error {
+	return parseTyped(s, a.AssetID.Parse, a)
+}
+
+func (a *ERC721AssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *ERC721AssetID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *ERC721AssetID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AssetID.UnmarshalJSON, a)
+}
+
+func (a *ERC721AssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *ERC721AssetID) Scan(src interface{}) error {
+	return scanParse(AssetIDKind, src, a.Parse)
+}
+
+func (a *ERC721AssetID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
 }
 
-func (a ERC721AssetID) Address() common.Address {
-	split := strings.Split(a.Reference, "/")
-	return common.HexToAddress(split[0])
+// Token returns the token id, or nil for an asset id of the whole collection.
+func (a ERC721AssetID) Token() *big.Int {
+	i, _ := parseUint256(a.TokenID)
+	return i
 }
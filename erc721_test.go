@@ -26,7 +26,7 @@ func TestERC721AssetID(t *testing.T) {
 			t.Errorf("Failed to serialize asset id to string")
 		}
 
-		if _, err := NewERC721AssetID(a.ChainID, a.AssetID.Namespace, a.AssetID.Reference); err != nil {
+		if _, err := NewERC721AssetID(a.ChainID, a.AssetID.Namespace, joinTokenID(a.AssetID.Reference, a.TokenID)); err != nil {
 			t.Errorf("Failed to create asset id from address")
 		}
 
@@ -76,8 +76,8 @@ func TestInvalidERC721AssetID(t *testing.T) {
 		err: fmt.Errorf("invalid token id: %s", "cat"),
 	}} {
 		a := ERC721AssetID{}
-		if err := a.Parse(tc.id); err != nil {
-			t.Errorf("Failed to parse asset id")
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse asset id should error")
 		}
 
 		if a.String() != tc.id {
@@ -93,7 +93,7 @@ func TestInvalidERC721AssetID(t *testing.T) {
 			t.Errorf("expected error: %s", tc.err)
 		}
 
-		_, err = NewERC721AssetID(a.ChainID, a.AssetID.Namespace, a.AssetID.Reference)
+		_, err = NewERC721AssetID(a.ChainID, a.AssetID.Namespace, joinTokenID(a.AssetID.Reference, a.TokenID))
 		if err == nil {
 			t.Errorf("Create asset id should error")
 		}
@@ -103,3 +103,22 @@ func TestInvalidERC721AssetID(t *testing.T) {
 		}
 	}
 }
+
+func TestERC721AssetIDFromAssetID(t *testing.T) {
+	aID := AssetID{}
+	aID.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769")
+
+	a, err := ERC721AssetIDFromAssetID(aID)
+	if err != nil {
+		t.Fatalf("Failed to convert asset id: %v", err)
+	}
+
+	if a.TokenID != "771769" || a.String() != aID.String() {
+		t.Errorf("Converted asset id invalid: %s", a)
+	}
+
+	aID.ParseX("eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f")
+	if _, err := ERC721AssetIDFromAssetID(aID); err == nil {
+		t.Errorf("Converting erc20 asset id should error")
+	}
+}
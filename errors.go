@@ -0,0 +1,48 @@
+package caip
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Kind string
+
+const (
+	ChainIDKind   Kind = "chain id"
+	AccountIDKind Kind = "account id"
+	AssetTypeKind Kind = "asset type"
+	AssetIDKind   Kind = "asset id"
+)
+
+var (
+	ErrMalformed        = errors.New("malformed identifier")
+	ErrNamespaceInvalid = errors.New("namespace does not match spec")
+	ErrReferenceInvalid = errors.New("reference does not match spec")
+	ErrAddressInvalid   = errors.New("address does not match spec")
+	ErrTokenIDInvalid   = errors.New("token id does not match spec")
+)
+
+// ValidationError reports which component of an identifier failed validation.
+// Offset is the byte offset of the field within the identifier's string form.
+type ValidationError struct {
+	Kind   Kind
+	Field  string
+	Value  string
+	Offset int
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid %s %q at offset %d: %s", e.Kind, e.Value, e.Offset, e.Err)
+	}
+	return fmt.Sprintf("invalid %s %s %q at offset %d: %s", e.Kind, e.Field, e.Value, e.Offset, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func malformedError(kind Kind, s string) error {
+	return &ValidationError{Kind: kind, Value: s, Offset: len(s), Err: ErrMalformed}
+}
@@ -0,0 +1,130 @@
+package caip
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	for _, tc := range []struct {
+		id     string
+		parse  func(string) error
+		kind   Kind
+		field  string
+		offset int
+		err    error
+	}{{
+		id:     "EIP155!!:1",
+		parse:  new(ChainID).Parse,
+		kind:   ChainIDKind,
+		field:  "namespace",
+		offset: 0,
+		err:    ErrNamespaceInvalid,
+	}, {
+		id:     "eip155:" + strings.Repeat("1", 200),
+		parse:  new(ChainID).Parse,
+		kind:   ChainIDKind,
+		field:  "reference",
+		offset: 7,
+		err:    ErrReferenceInvalid,
+	}, {
+		id:     "eip155",
+		parse:  new(ChainID).Parse,
+		kind:   ChainIDKind,
+		offset: 6,
+		err:    ErrMalformed,
+	}, {
+		id:     "eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb!",
+		parse:  new(AccountID).Parse,
+		kind:   AccountIDKind,
+		field:  "account_address",
+		offset: 9,
+		err:    ErrAddressInvalid,
+	}, {
+		id:     "eip155:1!:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb",
+		parse:  new(AccountID).Parse,
+		kind:   ChainIDKind,
+		field:  "reference",
+		offset: 7,
+		err:    ErrReferenceInvalid,
+	}, {
+		id:     "eip155:1/ERC20:0x6b175474e89094c44da98b954eedeac495271d0f",
+		parse:  new(AssetID).Parse,
+		kind:   AssetIDKind,
+		field:  "asset_namespace",
+		offset: 9,
+		err:    ErrNamespaceInvalid,
+	}, {
+		id:     "eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f?",
+		parse:  new(AssetID).Parse,
+		kind:   AssetIDKind,
+		field:  "asset_reference",
+		offset: 15,
+		err:    ErrReferenceInvalid,
+	}, {
+		id:     "eip155/erc20:0x6b175474e89094c44da98b954eedeac495271d0f",
+		parse:  new(AssetID).Parse,
+		kind:   AssetIDKind,
+		offset: 55,
+		err:    ErrMalformed,
+	}} {
+		err := tc.parse(tc.id)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected error %v, got %v", tc.id, tc.err, err)
+			continue
+		}
+
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%s: expected validation error, got %T", tc.id, err)
+			continue
+		}
+
+		if verr.Kind != tc.kind || verr.Field != tc.field || verr.Offset != tc.offset {
+			t.Errorf("%s: unexpected error details: %+v", tc.id, verr)
+		}
+	}
+}
+
+// The boundaries of the CAIP-2, CAIP-10 and CAIP-19 grammars, for a chain
+// namespace without a registered profile.
+func TestCAIPGrammar(t *testing.T) {
+	const chain = "chainstd:8c3444cf8970a9e41a706fab93e7a6c4"
+
+	for _, tc := range []struct {
+		id    string
+		parse func(string) error
+		err   error
+	}{
+		{"abc:1", new(ChainID).Parse, nil},
+		{"ab:1", new(ChainID).Parse, ErrNamespaceInvalid},
+		{"abc-1234:1", new(ChainID).Parse, nil},
+		{"abc-12345:1", new(ChainID).Parse, ErrNamespaceInvalid},
+		{"abc:" + strings.Repeat("a", 32), new(ChainID).Parse, nil},
+		{"abc:" + strings.Repeat("a", 33), new(ChainID).Parse, ErrReferenceInvalid},
+		{"abc:a-b_C", new(ChainID).Parse, nil},
+		{"abc:a.b", new(ChainID).Parse, ErrReferenceInvalid},
+
+		{chain + ":0.0.1234", new(AccountID).Parse, nil},
+		{chain + ":a-b%20C", new(AccountID).Parse, nil},
+		{chain + ":" + strings.Repeat("a", 128), new(AccountID).Parse, nil},
+		{chain + ":" + strings.Repeat("a", 129), new(AccountID).Parse, ErrAddressInvalid},
+		{chain + ":a_b", new(AccountID).Parse, ErrAddressInvalid},
+
+		{chain + "/token:a-b.c%20D", new(AssetID).Parse, nil},
+		{chain + "/token:" + strings.Repeat("a", 128), new(AssetID).Parse, nil},
+		{chain + "/token:" + strings.Repeat("a", 129), new(AssetID).Parse, ErrReferenceInvalid},
+		{chain + "/token:a_b", new(AssetID).Parse, ErrReferenceInvalid},
+		{chain + "/to:a", new(AssetID).Parse, ErrNamespaceInvalid},
+		{chain + "/token:a/1.2-3%20", new(AssetID).Parse, nil},
+		{chain + "/token:a/" + strings.Repeat("1", 78), new(AssetID).Parse, nil},
+		{chain + "/token:a/" + strings.Repeat("1", 79), new(AssetID).Parse, ErrTokenIDInvalid},
+		{chain + "/token:a/", new(AssetID).Parse, ErrTokenIDInvalid},
+		{chain + "/token:a//", new(AssetID).Parse, ErrTokenIDInvalid},
+	} {
+		if err := tc.parse(tc.id); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected error %v, got %v", tc.id, tc.err, err)
+		}
+	}
+}
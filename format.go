@@ -0,0 +1,48 @@
+package caip
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// format implements fmt.Formatter for identifiers. %+v prints the components
+// of v, a conversion of the identifier to a type without a Format method, and
+// %#v prints them as a Go literal of the named type. Other verbs format s,
+// the best-effort string form, as a string.
+func format(f fmt.State, verb rune, name, s string, v interface{}) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		goSyntax := fmt.Sprintf("%#v", v)
+		fmt.Fprint(f, "caip."+name+strings.TrimPrefix(goSyntax, fmt.Sprintf("%T", v)))
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "%+v", v)
+	case verb == 'v' || verb == 's' || verb == 'q':
+		if verb == 'v' {
+			verb = 's'
+		}
+		fmt.Fprintf(f, formatString(f, verb), s)
+	default:
+		fmt.Fprintf(f, "%%!%c(caip.%s=%s)", verb, name, s)
+	}
+}
+
+// formatString rebuilds the directive f was created from, with verb.
+func formatString(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(precision))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package caip
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStringInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		v   fmt.Stringer
+		str string
+	}{{
+		v:   ChainID{},
+		str: ":",
+	}, {
+		v:   UnsafeChainID("EIP155", "1"),
+		str: "EIP155:1",
+	}, {
+		v:   AccountID{},
+		str: "::",
+	}, {
+		v:   UnsafeAccountID(ChainID{"eip155", "1"}, "0xnope"),
+		str: "eip155:1:0xnope",
+	}, {
+		v:   AssetID{},
+		str: ":/:",
+	}, {
+		v:   AssetType{ChainID{"eip155", "1"}, "erc20", "x"},
+		str: "eip155:1/erc20:x",
+	}} {
+		if s := tc.v.String(); s != tc.str {
+			t.Errorf("expected %s, got: %s", tc.str, s)
+		}
+
+		if s := fmt.Sprintf("%v", tc.v); s != tc.str {
+			t.Errorf("expected %s, got: %s", tc.str, s)
+		}
+
+		if _, err := tc.v.(interface{ Canonical() (string, error) }).Canonical(); err == nil {
+			t.Errorf("%s: canonical should error", tc.str)
+		}
+	}
+
+	if _, err := (AccountID{}).Value(); err == nil {
+		t.Errorf("Value of invalid account id should error")
+	}
+
+	if _, err := (Amount{}).Value(); err == nil {
+		t.Errorf("Value of invalid amount should error")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	aID := AccountID{}
+	aID.ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+
+	for _, tc := range []struct {
+		format string
+		out    string
+	}{{
+		format: "%v",
+		out:    "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+	}, {
+		format: "%s",
+		out:    "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+	}, {
+		format: "%q",
+		out:    `"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb"`,
+	}, {
+		format: "%.8s|",
+		out:    "eip155:1|",
+	}, {
+		format: "%-10v|",
+		out:    "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb|",
+	}, {
+		format: "%+v",
+		out:    "{ChainID:{Namespace:eip155 Reference:1} Address:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb}",
+	}, {
+		format: "%#v",
+		out:    `caip.AccountID{ChainID:caip.ChainID{Namespace:"eip155", Reference:"1"}, Address:"0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb"}`,
+	}, {
+		format: "%d",
+		out:    "%!d(caip.AccountID=eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb)",
+	}} {
+		if s := fmt.Sprintf(tc.format, aID); s != tc.out {
+			t.Errorf("%s: expected %s, got: %s", tc.format, tc.out, s)
+		}
+	}
+
+	c := ChainID{"eip155", "1"}
+	if s := fmt.Sprintf("%-10s|", c); s != "eip155:1  |" {
+		t.Errorf("Padded chain id invalid: %s", s)
+	}
+
+	if s := fmt.Sprintf("%+v", AssetID{}); s != "{ChainID:{Namespace: Reference:} Namespace: Reference: TokenID:}" {
+		t.Errorf("Zero asset id components invalid: %s", s)
+	}
+}
@@ -2,4 +2,7 @@ module github.com/ChainAgnostic/go-caip
 
 go 1.16
 
-require github.com/ethereum/go-ethereum v1.10.26
+require (
+	github.com/ethereum/go-ethereum v1.10.26
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
+)
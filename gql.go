@@ -0,0 +1,57 @@
+package caip
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func marshalGQL(w io.Writer, s string) {
+	fmt.Fprint(w, strconv.Quote(s))
+}
+
+func unmarshalGQL(kind Kind, v interface{}, parse func(string) error) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("unmarshalling %s: expected string, got %T", kind, v)
+	}
+
+	if err := parse(s); err != nil {
+		return fmt.Errorf("unmarshalling %s: %w", kind, err)
+	}
+
+	return nil
+}
+
+// LegacyGQLChainID is a chain id that MarshalGQL upper-cases as earlier
+// versions did, for clients that depend on the old output.
+type LegacyGQLChainID struct {
+	ChainID
+}
+
+func (c LegacyGQLChainID) MarshalGQL(w io.Writer) {
+	marshalGQL(w, strings.ToUpper(c.String()))
+}
+
+// LegacyGQLAccountID is an account id that MarshalGQL upper-cases as earlier
+// versions did. Upper-cased account ids do not round-trip for case-sensitive
+// addresses.
+type LegacyGQLAccountID struct {
+	AccountID
+}
+
+func (c LegacyGQLAccountID) MarshalGQL(w io.Writer) {
+	marshalGQL(w, strings.ToUpper(c.String()))
+}
+
+// LegacyGQLAssetID is an asset id that MarshalGQL upper-cases as earlier
+// versions did. Upper-cased asset ids do not round-trip for case-sensitive
+// references.
+type LegacyGQLAssetID struct {
+	AssetID
+}
+
+func (a LegacyGQLAssetID) MarshalGQL(w io.Writer) {
+	marshalGQL(w, strings.ToUpper(a.String()))
+}
@@ -0,0 +1,68 @@
+package caip
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestMarshalGQL(t *testing.T) {
+	aID := AssetID{}
+	aID.ParseX("solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+	for _, tc := range []struct {
+		v   interface{ MarshalGQL(io.Writer) }
+		out string
+	}{{
+		v:   aID,
+		out: `"solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"`,
+	}, {
+		v:   LegacyGQLAssetID{aID},
+		out: `"SOLANA:5EYKT4USFV8P8NJDTREPY1VZQKQZKVDP/TOKEN:EPJFWDD5AUFQSSQEM2QN1XZYBAPC8G4WEGGKZWYTDT1V"`,
+	}, {
+		v:   LegacyGQLChainID{aID.ChainID},
+		out: `"SOLANA:5EYKT4USFV8P8NJDTREPY1VZQKQZKVDP"`,
+	}, {
+		v:   LegacyGQLAccountID{UnsafeAccountID(ChainID{"eip155", "1"}, "0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")},
+		out: `"EIP155:1:0XAB16A96D359EC26A11E2C2B3D8F8B8942D5BFCDB"`,
+	}} {
+		var b bytes.Buffer
+		tc.v.MarshalGQL(&b)
+		if b.String() != tc.out {
+			t.Errorf("expected %s, got: %s", tc.out, b.String())
+		}
+	}
+
+	a2 := AssetID{}
+	if err := a2.UnmarshalGQL(aID.String()); err != nil {
+		t.Fatalf("Failed to unmarshal gql: %v", err)
+	}
+
+	if a2 != aID {
+		t.Errorf("Unmarshalled asset id invalid: %s", a2)
+	}
+}
+
+func TestUnmarshalGQLNonString(t *testing.T) {
+	for _, v := range []interface{}{nil, 1, true, map[string]interface{}{"namespace": "eip155"}} {
+		if err := new(ChainID).UnmarshalGQL(v); err == nil {
+			t.Errorf("%v: unmarshal chain id should error", v)
+		}
+
+		if err := new(AccountID).UnmarshalGQL(v); err == nil {
+			t.Errorf("%v: unmarshal account id should error", v)
+		}
+
+		if err := new(AssetType).UnmarshalGQL(v); err == nil {
+			t.Errorf("%v: unmarshal asset type should error", v)
+		}
+
+		if err := new(AssetID).UnmarshalGQL(v); err == nil {
+			t.Errorf("%v: unmarshal asset id should error", v)
+		}
+
+		if err := new(Amount).UnmarshalGQL(v); err == nil {
+			t.Errorf("%v: unmarshal amount should error", v)
+		}
+	}
+}
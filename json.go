@@ -0,0 +1,94 @@
+package caip
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// unmarshalJSONString parses data with parse if it is a JSON string and
+// reports whether it was one.
+func unmarshalJSONString(data []byte, parse func(string) error) (bool, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		return false, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return true, err
+	}
+
+	return true, parse(s)
+}
+
+// unmarshalTypedJSON decodes either JSON form with decode, the UnmarshalJSON of
+// the generic identifier embedded in a typed wrapper, and then parses its
+// string form again with the Parse of the wrapper.
+func unmarshalTypedJSON(data []byte, decode func([]byte) error, v interface {
+	Parse(string) error
+	String() string
+}) error {
+	if err := decode(data); err != nil {
+		return err
+	}
+
+	return v.Parse(v.String())
+}
+
+// marshalJSONString marshals a valid identifier as its CAIP string.
+func marshalJSONString(v interface {
+	Validate() error
+	String() string
+}) ([]byte, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v.String())
+}
+
+// StringChainID is a chain id that marshals to JSON as its CAIP string, e.g.
+// "eip155:1", instead of an object of components. Like ChainID it unmarshals
+// from both forms.
+type StringChainID struct {
+	ChainID
+}
+
+func (c StringChainID) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(c.ChainID)
+}
+
+// StringAccountID is an account id that marshals to JSON as its CAIP string.
+type StringAccountID struct {
+	AccountID
+}
+
+func (c StringAccountID) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(c.AccountID)
+}
+
+// StringAssetType is an asset type that marshals to JSON as its CAIP string.
+type StringAssetType struct {
+	AssetType
+}
+
+func (a StringAssetType) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(a.AssetType)
+}
+
+// StringAssetID is an asset id that marshals to JSON as its CAIP string.
+type StringAssetID struct {
+	AssetID
+}
+
+func (a StringAssetID) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(a.AssetID)
+}
+
+// StringAmount is an amount that marshals to JSON as its string form, e.g.
+// "1.500000 eip155:1/erc20:0xa0b8…".
+type StringAmount struct {
+	Amount
+}
+
+func (a StringAmount) MarshalJSON() ([]byte, error) {
+	return marshalJSONString(a.Amount)
+}
@@ -0,0 +1,103 @@
+package caip
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONStringForm(t *testing.T) {
+	type session struct {
+		Chain   StringChainID   `json:"chain"`
+		Account StringAccountID `json:"account"`
+		Asset   StringAssetID   `json:"asset"`
+		Type    StringAssetType `json:"type"`
+	}
+
+	s := session{}
+	s.Chain.ParseX("eip155:1")
+	s.Account.ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+	s.Asset.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769")
+	s.Type.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d")
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	expected := `{"chain":"eip155:1","account":"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb","asset":"eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769","type":"eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d"}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got: %s", expected, b)
+	}
+
+	s2 := session{}
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("Failed to unmarshal string form: %v", err)
+	}
+
+	if s2 != s {
+		t.Errorf("Unmarshalled string form invalid: %+v", s2)
+	}
+
+	// The plain types keep the object form and both forms decode into either
+	b, err = json.Marshal(struct {
+		Chain   ChainID   `json:"chain"`
+		Account AccountID `json:"account"`
+		Asset   AssetID   `json:"asset"`
+		Type    AssetType `json:"type"`
+	}{s.Chain.ChainID, s.Account.AccountID, s.Asset.AssetID, s.Type.AssetType})
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	if string(b) == expected {
+		t.Errorf("Plain types should marshal to objects: %s", b)
+	}
+
+	s2 = session{}
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("Failed to unmarshal object form: %v", err)
+	}
+
+	if s2 != s {
+		t.Errorf("Unmarshalled object form invalid: %+v", s2)
+	}
+
+	if _, err := json.Marshal(StringChainID{UnsafeChainID("EIP155", "1")}); err == nil {
+		t.Errorf("Marshal of invalid chain id should error")
+	}
+}
+
+func TestJSONStringFormInvalid(t *testing.T) {
+	for _, data := range []string{`"eip155"`, `"EIP155:1"`, `"eip155:1`, `""`} {
+		if err := json.Unmarshal([]byte(data), new(ChainID)); err == nil {
+			t.Errorf("%s: unmarshal chain id should error", data)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdx"`), new(EVMAccountID)); err == nil {
+		t.Errorf("unmarshal account id should error")
+	}
+}
+
+func TestAmountJSONStringForm(t *testing.T) {
+	a := Amount{}
+	a.ParseX("1.500000 eip155:1/erc20:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+	b, err := json.Marshal(StringAmount{a})
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	if string(b) != `"1.500000 eip155:1/erc20:0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"` {
+		t.Errorf("Marshalled amount invalid: %s", b)
+	}
+
+	a2 := Amount{}
+	if err := json.Unmarshal(b, &a2); err != nil {
+		t.Fatalf("Failed to unmarshal to json: %v", err)
+	}
+
+	if a2.String() != a.String() {
+		t.Errorf("Unmarshalled amount invalid: %s", a2)
+	}
+}
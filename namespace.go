@@ -0,0 +1,81 @@
+package caip
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Namespace holds the chain specific rules for a CAIP-2 namespace. AccountID
+// and AssetID validation dispatch to the Namespace registered for their chain
+// namespace after the generic grammar has been checked, so implementations
+// must not call Validate on the identifiers they receive.
+type Namespace interface {
+	ValidateReference(reference string) error
+	ValidateAddress(chainID ChainID, address string) error
+	NormalizeAddress(chainID ChainID, address string) (string, error)
+	ValidateAsset(assetID AssetID) error
+}
+
+// AssetNormalizer is implemented by a Namespace whose asset references or
+// token ids have more than one valid spelling. NormalizeAsset receives valid
+// asset ids only.
+type AssetNormalizer interface {
+	NormalizeAsset(assetID AssetID) (AssetID, error)
+}
+
+var (
+	namespacesMu sync.RWMutex
+	namespaces   = map[string]Namespace{}
+)
+
+// RegisterNamespace makes ns the profile for the given chain namespace,
+// replacing any previously registered profile including the built-in ones.
+func RegisterNamespace(name string, ns Namespace) {
+	if ns == nil {
+		panic("caip: RegisterNamespace namespace is nil")
+	}
+
+	if ok := validChainNamespace(name); !ok {
+		panic("caip: RegisterNamespace invalid namespace " + name)
+	}
+
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+	namespaces[name] = ns
+}
+
+func UnregisterNamespace(name string) {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+	delete(namespaces, name)
+}
+
+func LookupNamespace(name string) (Namespace, bool) {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+	ns, ok := namespaces[name]
+	return ns, ok
+}
+
+func Namespaces() []string {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namespaceError makes sure errors returned by a Namespace still match the
+// sentinel of the field they were reported for.
+func namespaceError(sentinel, err error) error {
+	if errors.Is(err, sentinel) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", sentinel, err)
+}
@@ -0,0 +1,114 @@
+package caip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type upperNamespace struct{}
+
+func (upperNamespace) ValidateReference(reference string) error {
+	if reference != "main" {
+		return fmt.Errorf("unknown network: %s", reference)
+	}
+	return nil
+}
+
+func (upperNamespace) ValidateAddress(chainID ChainID, address string) error {
+	if !strings.HasPrefix(strings.ToLower(address), "acc") {
+		return fmt.Errorf("%w: missing acc prefix", ErrAddressInvalid)
+	}
+	return nil
+}
+
+func (upperNamespace) NormalizeAddress(chainID ChainID, address string) (string, error) {
+	return strings.ToUpper(address), nil
+}
+
+func (upperNamespace) ValidateAsset(assetID AssetID) error {
+	if assetID.Namespace != "coin" {
+		return fmt.Errorf("unknown asset namespace: %s", assetID.Namespace)
+	}
+	return nil
+}
+
+func TestRegisterNamespace(t *testing.T) {
+	RegisterNamespace("upper", upperNamespace{})
+	defer UnregisterNamespace("upper")
+
+	if _, ok := LookupNamespace("upper"); !ok {
+		t.Fatalf("Registered namespace not found")
+	}
+
+	for _, tc := range []struct {
+		id    string
+		parse func(string) error
+		err   error
+	}{{
+		id:    "upper:main",
+		parse: new(ChainID).Parse,
+	}, {
+		id:    "upper:test",
+		parse: new(ChainID).Parse,
+		err:   ErrReferenceInvalid,
+	}, {
+		id:    "upper:main:acc123",
+		parse: new(AccountID).Parse,
+	}, {
+		id:    "upper:main:123",
+		parse: new(AccountID).Parse,
+		err:   ErrAddressInvalid,
+	}, {
+		id:    "upper:main/coin:1",
+		parse: new(AssetID).Parse,
+	}, {
+		id:    "upper:main/token:1",
+		parse: new(AssetID).Parse,
+		err:   ErrReferenceInvalid,
+	}} {
+		if err := tc.parse(tc.id); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected error %v, got %v", tc.id, tc.err, err)
+		}
+	}
+
+	a, err := NewAccountID(ChainID{"upper", "main"}, "acc123")
+	if err != nil {
+		t.Fatalf("Failed to create account id: %v", err)
+	}
+
+	a, err = a.Normalize()
+	if err != nil {
+		t.Fatalf("Failed to normalize account id: %v", err)
+	}
+
+	if a.Address != "ACC123" {
+		t.Errorf("Normalized address not valid: %s", a.Address)
+	}
+
+	UnregisterNamespace("upper")
+	if err := new(ChainID).Parse("upper:test"); err != nil {
+		t.Errorf("Unregistered namespace should fall back to generic rules: %v", err)
+	}
+}
+
+func TestRegisterNamespaceConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("conc%d", i)
+			RegisterNamespace(name, upperNamespace{})
+			defer UnregisterNamespace(name)
+
+			a := AccountID{}
+			if err := a.Parse(name + ":main:acc1"); err != nil {
+				t.Errorf("Failed to parse account id: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,228 @@
+package caip
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// NullChainID is a chain id that may be null, like sql.NullString.
+type NullChainID struct {
+	ChainID ChainID
+	Valid   bool
+}
+
+func (n *NullChainID) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullChainID{}
+		return nil
+	}
+
+	var c ChainID
+	if err := c.Scan(src); err != nil {
+		return err
+	}
+
+	*n = NullChainID{c, true}
+	return nil
+}
+
+func (n NullChainID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ChainID.Value()
+}
+
+func (n *NullChainID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullChainID{}
+		return nil
+	}
+
+	var c ChainID
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	*n = NullChainID{c, true}
+	return nil
+}
+
+func (n NullChainID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.ChainID)
+}
+
+func (n NullChainID) MarshalGQL(w io.Writer) {
+	if !n.Valid {
+		fmt.Fprint(w, "null")
+		return
+	}
+	n.ChainID.MarshalGQL(w)
+}
+
+func (n *NullChainID) UnmarshalGQL(v interface{}) error {
+	if v == nil {
+		*n = NullChainID{}
+		return nil
+	}
+
+	var c ChainID
+	if err := c.UnmarshalGQL(v); err != nil {
+		return err
+	}
+
+	*n = NullChainID{c, true}
+	return nil
+}
+
+// NullAccountID is an account id that may be null, like sql.NullString.
+type NullAccountID struct {
+	AccountID AccountID
+	Valid     bool
+}
+
+func (n *NullAccountID) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullAccountID{}
+		return nil
+	}
+
+	var a AccountID
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+
+	*n = NullAccountID{a, true}
+	return nil
+}
+
+func (n NullAccountID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.AccountID.Value()
+}
+
+func (n *NullAccountID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullAccountID{}
+		return nil
+	}
+
+	var a AccountID
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	*n = NullAccountID{a, true}
+	return nil
+}
+
+func (n NullAccountID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.AccountID)
+}
+
+func (n NullAccountID) MarshalGQL(w io.Writer) {
+	if !n.Valid {
+		fmt.Fprint(w, "null")
+		return
+	}
+	n.AccountID.MarshalGQL(w)
+}
+
+func (n *NullAccountID) UnmarshalGQL(v interface{}) error {
+	if v == nil {
+		*n = NullAccountID{}
+		return nil
+	}
+
+	var a AccountID
+	if err := a.UnmarshalGQL(v); err != nil {
+		return err
+	}
+
+	*n = NullAccountID{a, true}
+	return nil
+}
+
+// NullAssetID is an asset id that may be null, like sql.NullString.
+type NullAssetID struct {
+	AssetID AssetID
+	Valid   bool
+}
+
+func (n *NullAssetID) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullAssetID{}
+		return nil
+	}
+
+	var a AssetID
+	if err := a.Scan(src); err != nil {
+		return err
+	}
+
+	*n = NullAssetID{a, true}
+	return nil
+}
+
+func (n NullAssetID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.AssetID.Value()
+}
+
+func (n *NullAssetID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullAssetID{}
+		return nil
+	}
+
+	var a AssetID
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	*n = NullAssetID{a, true}
+	return nil
+}
+
+func (n NullAssetID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.AssetID)
+}
+
+func (n NullAssetID) MarshalGQL(w io.Writer) {
+	if !n.Valid {
+		fmt.Fprint(w, "null")
+		return
+	}
+	n.AssetID.MarshalGQL(w)
+}
+
+func (n *NullAssetID) UnmarshalGQL(v interface{}) error {
+	if v == nil {
+		*n = NullAssetID{}
+		return nil
+	}
+
+	var a AssetID
+	if err := a.UnmarshalGQL(v); err != nil {
+		return err
+	}
+
+	*n = NullAssetID{a, true}
+	return nil
+}
@@ -0,0 +1,123 @@
+package caip
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestNullChainID(t *testing.T) {
+	c := NullChainID{ChainID{"eip155", "1"}, true}
+	if err := c.Scan(nil); err != nil {
+		t.Fatalf("Failed to scan null: %v", err)
+	}
+
+	if c.Valid || c.ChainID != (ChainID{}) {
+		t.Errorf("Scanned null should reset chain id: %+v", c)
+	}
+
+	if v, err := c.Value(); err != nil || v != nil {
+		t.Errorf("Value of null chain id invalid: %v, %v", v, err)
+	}
+
+	if err := c.Scan([]byte("eip155:1")); err != nil {
+		t.Fatalf("Failed to scan bytes: %v", err)
+	}
+
+	if !c.Valid || c.ChainID.String() != "eip155:1" {
+		t.Errorf("Scanned chain id invalid: %+v", c)
+	}
+
+	if v, err := c.Value(); err != nil || v != "eip155:1" {
+		t.Errorf("Value of chain id invalid: %v, %v", v, err)
+	}
+
+	if err := c.Scan(int64(1)); err == nil {
+		t.Errorf("Scanning int64 should error")
+	}
+}
+
+func TestScanNull(t *testing.T) {
+	for _, s := range []interface{ Scan(interface{}) error }{
+		&ChainID{}, &AccountID{}, &AssetType{}, &AssetID{}, &Amount{},
+	} {
+		if err := s.Scan(nil); err == nil {
+			t.Errorf("%T: scanning null should error", s)
+		}
+	}
+}
+
+func TestNullJSON(t *testing.T) {
+	type row struct {
+		Chain   NullChainID   `json:"chain"`
+		Account NullAccountID `json:"account"`
+		Asset   NullAssetID   `json:"asset"`
+	}
+
+	r := row{}
+	r.Account.AccountID.ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+	r.Account.Valid = true
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Failed to marshal to json: %v", err)
+	}
+
+	expected := `{"chain":null,"account":{"chain_id":{"namespace":"eip155","reference":"1"},"account_address":"0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb"},"asset":null}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got: %s", expected, b)
+	}
+
+	r2 := row{Chain: NullChainID{ChainID{"eip155", "1"}, true}}
+	if err := json.Unmarshal(b, &r2); err != nil {
+		t.Fatalf("Failed to unmarshal to json: %v", err)
+	}
+
+	if r2 != r {
+		t.Errorf("Unmarshalled row invalid: %+v", r2)
+	}
+
+	if err := json.Unmarshal([]byte(`{"asset":"eip155:1/slip44:60"}`), &r2); err != nil {
+		t.Fatalf("Failed to unmarshal string form: %v", err)
+	}
+
+	if !r2.Asset.Valid || r2.Asset.AssetID.String() != "eip155:1/slip44:60" {
+		t.Errorf("Unmarshalled asset invalid: %+v", r2.Asset)
+	}
+
+	if err := json.Unmarshal([]byte(`{"asset":"eip155:1"}`), &r2); err == nil {
+		t.Errorf("Unmarshal invalid asset should error")
+	}
+}
+
+func TestNullGQL(t *testing.T) {
+	a := NullAssetID{}
+
+	var b bytes.Buffer
+	a.MarshalGQL(&b)
+	if b.String() != "null" {
+		t.Errorf("Marshalled null invalid: %s", b.String())
+	}
+
+	if err := a.UnmarshalGQL("eip155:1/slip44:60"); err != nil {
+		t.Fatalf("Failed to unmarshal gql: %v", err)
+	}
+
+	if !a.Valid {
+		t.Errorf("Unmarshalled asset id should be valid")
+	}
+
+	b.Reset()
+	a.MarshalGQL(&b)
+	if b.String() != `"eip155:1/slip44:60"` {
+		t.Errorf("Marshalled asset id invalid: %s", b.String())
+	}
+
+	if err := a.UnmarshalGQL(nil); err != nil || a.Valid {
+		t.Errorf("Unmarshalled null invalid: %+v, %v", a, err)
+	}
+
+	if err := a.UnmarshalGQL(1); err == nil {
+		t.Errorf("Unmarshalling int should error")
+	}
+}
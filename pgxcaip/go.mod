@@ -0,0 +1,19 @@
+module github.com/ChainAgnostic/go-caip/pgxcaip
+
+go 1.19
+
+require (
+	github.com/ChainAgnostic/go-caip v0.0.0
+	github.com/jackc/pgx/v5 v5.5.5
+)
+
+require (
+	github.com/ethereum/go-ethereum v1.10.26 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
+	golang.org/x/crypto v0.17.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/ChainAgnostic/go-caip => ../
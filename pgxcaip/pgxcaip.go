@@ -0,0 +1,223 @@
+// Package pgxcaip registers pgx v5 codecs for CAIP identifiers.
+//
+// Identifiers are stored as text, preferably in the domains defined by Schema.
+// Once registered, chain_id, account_id, asset_type and asset_id values and
+// their arrays encode from and scan into the caip types directly:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		return pgxcaip.Register(ctx, conn)
+//	}
+//
+//	var accounts []caip.AccountID
+//	rows, err := pool.Query(ctx, "SELECT * FROM balances WHERE account = ANY($1)", accounts)
+package pgxcaip
+
+import (
+	"context"
+	"database/sql/driver"
+	_ "embed"
+	"encoding"
+	"fmt"
+	"reflect"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Schema creates the chain_id, account_id, asset_type and asset_id domains.
+//
+//go:embed schema.sql
+var Schema string
+
+// domain is a domain created by Schema with its codec and Go types.
+type domain struct {
+	name  string
+	codec pgtype.Codec
+	value interface{}
+	array interface{}
+}
+
+var domains = []domain{
+	{"chain_id", ChainIDCodec{}, caip.ChainID{}, []caip.ChainID{}},
+	{"account_id", AccountIDCodec{}, caip.AccountID{}, []caip.AccountID{}},
+	{"asset_type", AssetTypeCodec{}, caip.AssetType{}, []caip.AssetType{}},
+	{"asset_id", AssetIDCodec{}, caip.AssetID{}, []caip.AssetID{}},
+}
+
+// register registers the codecs of the domain and its array type, and makes
+// them the default types of the identifier and its slices.
+func (d domain) register(m *pgtype.Map, oid, arrayOID uint32) {
+	RegisterType(m, d.name, oid, arrayOID, d.codec)
+	m.RegisterDefaultPgType(d.value, d.name)
+	m.RegisterDefaultPgType(d.array, "_"+d.name)
+}
+
+// Register loads the OIDs of the domains created by Schema and registers
+// codecs for them and their array types on conn.
+func Register(ctx context.Context, conn *pgx.Conn) error {
+	for _, d := range domains {
+		var oid, arrayOID uint32
+		err := conn.QueryRow(ctx, "SELECT oid, typarray FROM pg_type WHERE oid = $1::text::regtype", d.name).Scan(&oid, &arrayOID)
+		if err != nil {
+			return fmt.Errorf("loading type %s: %w", d.name, err)
+		}
+
+		d.register(conn.TypeMap(), oid, arrayOID)
+	}
+
+	return nil
+}
+
+// RegisterType registers codec for the type name with oid and, if arrayOID is
+// not zero, its array type.
+func RegisterType(m *pgtype.Map, name string, oid, arrayOID uint32, codec pgtype.Codec) {
+	t := &pgtype.Type{Name: name, OID: oid, Codec: codec}
+	m.RegisterType(t)
+
+	if arrayOID != 0 {
+		m.RegisterType(&pgtype.Type{Name: "_" + name, OID: arrayOID, Codec: &pgtype.ArrayCodec{ElementType: t}})
+	}
+}
+
+// textCodec implements pgtype.Codec for identifiers in their string form. The
+// binary format of text is the same UTF-8 string, so both formats are
+// supported.
+type textCodec struct{}
+
+func (textCodec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+func (textCodec) PreferredFormat() int16 {
+	return pgtype.TextFormatCode
+}
+
+func (textCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	// Pointers are dereferenced by pgx before retrying the plan
+	if reflect.ValueOf(value).Kind() == reflect.Ptr {
+		return nil
+	}
+
+	switch value.(type) {
+	case encoding.TextMarshaler:
+		return encodePlanText{}
+	case string:
+		return encodePlanString{}
+	}
+
+	return nil
+}
+
+// encodePlanText encodes identifiers, including the typed ones, in the form
+// they were given, like Value does for database/sql.
+type encodePlanText struct{}
+
+func (encodePlanText) Encode(value interface{}, buf []byte) ([]byte, error) {
+	text, err := value.(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, text...), nil
+}
+
+type encodePlanString struct{}
+
+func (encodePlanString) Encode(value interface{}, buf []byte) ([]byte, error) {
+	return append(buf, value.(string)...), nil
+}
+
+func (textCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	// Null identifiers and other sql.Scanners fall back to
+	// DecodeDatabaseSQLValue
+	switch target.(type) {
+	case encoding.TextUnmarshaler:
+		return scanPlanTextUnmarshaler{}
+	case *string:
+		return scanPlanString{}
+	}
+
+	return nil
+}
+
+type scanPlanTextUnmarshaler struct{}
+
+func (scanPlanTextUnmarshaler) Scan(src []byte, target interface{}) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	return target.(encoding.TextUnmarshaler).UnmarshalText(src)
+}
+
+type scanPlanString struct{}
+
+func (scanPlanString) Scan(src []byte, target interface{}) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	*target.(*string) = string(src)
+	return nil
+}
+
+func (textCodec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+	return string(src), nil
+}
+
+type ChainIDCodec struct{ textCodec }
+
+func (ChainIDCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var c caip.ChainID
+	if err := c.Parse(string(src)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type AccountIDCodec struct{ textCodec }
+
+func (AccountIDCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var a caip.AccountID
+	if err := a.Parse(string(src)); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+type AssetTypeCodec struct{ textCodec }
+
+func (AssetTypeCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var a caip.AssetType
+	if err := a.Parse(string(src)); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+type AssetIDCodec struct{ textCodec }
+
+func (AssetIDCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var a caip.AssetID
+	if err := a.Parse(string(src)); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
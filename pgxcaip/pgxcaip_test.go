@@ -0,0 +1,224 @@
+package pgxcaip
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	accountIDOID      = 100001
+	accountIDArrayOID = 100002
+)
+
+func testMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	RegisterType(m, "account_id", accountIDOID, accountIDArrayOID, AccountIDCodec{})
+	return m
+}
+
+func TestCodec(t *testing.T) {
+	m := testMap()
+
+	aID := caip.AccountID{}
+	aID.ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, v := range []interface{}{aID, &aID, aID.String()} {
+			buf, err := m.Encode(accountIDOID, format, v, nil)
+			if err != nil {
+				t.Fatalf("Failed to encode %T: %v", v, err)
+			}
+
+			if string(buf) != aID.String() {
+				t.Errorf("Encoded %T invalid: %s", v, buf)
+			}
+		}
+
+		var a2 caip.AccountID
+		if err := m.Scan(accountIDOID, format, []byte(aID.String()), &a2); err != nil {
+			t.Fatalf("Failed to scan account id: %v", err)
+		}
+
+		if a2 != aID {
+			t.Errorf("Scanned account id invalid: %s", a2)
+		}
+
+		var evm caip.EVMAccountID
+		if err := m.Scan(accountIDOID, format, []byte("eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb"), &evm); err != nil {
+			t.Fatalf("Failed to scan evm account id: %v", err)
+		}
+
+		if evm.AccountID != aID {
+			t.Errorf("Scanned evm account id not checksummed: %s", evm.AccountID)
+		}
+
+		var null caip.NullAccountID
+		if err := m.Scan(accountIDOID, format, nil, &null); err != nil || null.Valid {
+			t.Errorf("Scanned null invalid: %+v, %v", null, err)
+		}
+
+		if err := m.Scan(accountIDOID, format, []byte(aID.String()), &null); err != nil || null.AccountID != aID {
+			t.Errorf("Scanned nullable account id invalid: %+v, %v", null, err)
+		}
+
+		if err := m.Scan(accountIDOID, format, nil, &a2); err == nil {
+			t.Errorf("Scanning NULL into account id should error")
+		}
+
+		if err := m.Scan(accountIDOID, format, []byte("eip155:1"), &a2); err == nil {
+			t.Errorf("Scanning invalid account id should error")
+		}
+	}
+
+	if _, err := m.Encode(accountIDOID, pgtype.TextFormatCode, caip.UnsafeAccountID(caip.ChainID{Namespace: "eip155", Reference: "1"}, "0xnope"), nil); err == nil {
+		t.Errorf("Encoding invalid account id should error")
+	}
+
+	v, err := AccountIDCodec{}.DecodeValue(m, accountIDOID, pgtype.TextFormatCode, []byte(aID.String()))
+	if err != nil || v != aID {
+		t.Errorf("Decoded value invalid: %v, %v", v, err)
+	}
+}
+
+func TestArrayCodec(t *testing.T) {
+	m := testMap()
+
+	accounts := make([]caip.AccountID, 2)
+	accounts[0].ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+	accounts[1].ParseX("solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv")
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(accountIDArrayOID, format, accounts, nil)
+		if err != nil {
+			t.Fatalf("Failed to encode array: %v", err)
+		}
+
+		var accounts2 []caip.AccountID
+		if err := m.Scan(accountIDArrayOID, format, buf, &accounts2); err != nil {
+			t.Fatalf("Failed to scan array: %v", err)
+		}
+
+		if len(accounts2) != 2 || accounts2[0] != accounts[0] || accounts2[1] != accounts[1] {
+			t.Errorf("Scanned array invalid: %v", accounts2)
+		}
+	}
+}
+
+// TestDomains checks the codecs and default types Register sets up, without a
+// database.
+func TestDomains(t *testing.T) {
+	m := pgtype.NewMap()
+	for i, d := range domains {
+		d.register(m, uint32(200001+2*i), uint32(200002+2*i))
+	}
+
+	chainID := caip.ChainID{}
+	chainID.ParseX("eip155:1")
+	accountID := caip.AccountID{}
+	accountID.ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+	assetID := caip.AssetID{}
+	assetID.ParseX("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769")
+
+	for _, tc := range []struct {
+		name   string
+		value  interface{}
+		target func() interface{}
+	}{
+		{"chain_id", chainID, func() interface{} { return new(caip.ChainID) }},
+		{"_chain_id", []caip.ChainID{chainID}, func() interface{} { return new([]caip.ChainID) }},
+		{"account_id", accountID, func() interface{} { return new(caip.AccountID) }},
+		{"_account_id", []caip.AccountID{accountID, accountID}, func() interface{} { return new([]caip.AccountID) }},
+		{"asset_type", assetID.AssetType(), func() interface{} { return new(caip.AssetType) }},
+		{"_asset_type", []caip.AssetType{assetID.AssetType()}, func() interface{} { return new([]caip.AssetType) }},
+		{"asset_id", assetID, func() interface{} { return new(caip.AssetID) }},
+		{"_asset_id", []caip.AssetID{assetID}, func() interface{} { return new([]caip.AssetID) }},
+	} {
+		// Parameters of unknown type, e.g. ANY($1), use the default type
+		typ, ok := m.TypeForValue(tc.value)
+		if !ok || typ.Name != tc.name {
+			t.Errorf("%T: expected default type %s, got: %v", tc.value, tc.name, typ)
+			continue
+		}
+
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			buf, err := m.Encode(typ.OID, format, tc.value, nil)
+			if err != nil {
+				t.Fatalf("%s: failed to encode: %v", tc.name, err)
+			}
+
+			target := tc.target()
+			if err := m.Scan(typ.OID, format, buf, target); err != nil {
+				t.Fatalf("%s: failed to scan: %v", tc.name, err)
+			}
+
+			if got := reflect.ValueOf(target).Elem().Interface(); !reflect.DeepEqual(got, tc.value) {
+				t.Errorf("%s: scanned value invalid: %v", tc.name, got)
+			}
+		}
+	}
+}
+
+// TestPostgres runs against the database in PGXCAIP_TEST_DATABASE, e.g.
+// "postgres://localhost/caip_test".
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("PGXCAIP_TEST_DATABASE")
+	if dsn == "" {
+		t.Skip("PGXCAIP_TEST_DATABASE not set")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, Schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	if err := Register(ctx, conn); err != nil {
+		t.Fatalf("Failed to register types: %v", err)
+	}
+
+	if _, err := tx.Exec(ctx, "CREATE TEMP TABLE balances (account account_id, asset asset_id)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	aID := caip.AccountID{}
+	aID.ParseX("eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb")
+
+	asset := caip.AssetID{}
+	asset.ParseX("eip155:1/slip44:60")
+
+	if _, err := tx.Exec(ctx, "INSERT INTO balances VALUES ($1, $2)", aID, asset); err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+
+	var asset2 caip.AssetID
+	err = tx.QueryRow(ctx, "SELECT asset FROM balances WHERE account = ANY($1)", []caip.AccountID{aID}).Scan(&asset2)
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+
+	if asset2 != asset {
+		t.Errorf("Queried asset id invalid: %s", asset2)
+	}
+
+	// A failed statement aborts the transaction, so this goes last
+	if _, err := tx.Exec(ctx, "INSERT INTO balances VALUES ('eip155', NULL)"); err == nil {
+		t.Errorf("Domain check should reject invalid account id")
+	}
+}
@@ -0,0 +1,15 @@
+-- Domains for CAIP identifiers stored in their string form. The checks follow
+-- the CAIP-2, CAIP-10 and CAIP-19 grammar; namespace specific rules such as
+-- address checksums are left to the application.
+
+CREATE DOMAIN chain_id AS text
+    CHECK (VALUE ~ '^[-a-z0-9]{3,8}:[-_a-zA-Z0-9]{1,32}$');
+
+CREATE DOMAIN account_id AS text
+    CHECK (VALUE ~ '^[-a-z0-9]{3,8}:[-_a-zA-Z0-9]{1,32}:[-.%a-zA-Z0-9]{1,128}$');
+
+CREATE DOMAIN asset_type AS text
+    CHECK (VALUE ~ '^[-a-z0-9]{3,8}:[-_a-zA-Z0-9]{1,32}/[-a-z0-9]{3,8}:[-.%a-zA-Z0-9]{1,128}$');
+
+CREATE DOMAIN asset_id AS text
+    CHECK (VALUE ~ '^[-a-z0-9]{3,8}:[-_a-zA-Z0-9]{1,32}/[-a-z0-9]{3,8}:[-.%a-zA-Z0-9]{1,128}(/[-.%a-zA-Z0-9]{1,78})?$');
@@ -0,0 +1,243 @@
+package caip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	polkadotReferenceRegex = regexp.MustCompile("^[0-9a-f]{32}$")
+	ss58PrefixesMu         sync.RWMutex
+	ss58Prefixes           = map[string]uint16{
+		// Polkadot
+		"91b171bb158e2d3848fa23a9f1c25182": 0,
+		// Kusama
+		"b0a8d493285c2df73290dfb7e61f870f": 2,
+		// Westend
+		"e143f23803ac50e8f6f8e62695d1ce9e": 42,
+		// Astar
+		"9eb76c5184c4ab8679d2d5d819fdf90b": 5,
+	}
+
+	errSS58Invalid = errors.New("invalid ss58 address")
+)
+
+type polkadotNamespace struct{}
+
+func init() {
+	RegisterNamespace("polkadot", polkadotNamespace{})
+}
+
+// RegisterSS58Prefix sets the SS58 network prefix account addresses on the
+// chain with the given reference must use.
+func RegisterSS58Prefix(reference string, prefix uint16) {
+	ss58PrefixesMu.Lock()
+	defer ss58PrefixesMu.Unlock()
+	ss58Prefixes[reference] = prefix
+}
+
+func SS58Prefix(reference string) (uint16, bool) {
+	ss58PrefixesMu.RLock()
+	defer ss58PrefixesMu.RUnlock()
+	prefix, ok := ss58Prefixes[reference]
+	return prefix, ok
+}
+
+func (polkadotNamespace) ValidateReference(reference string) error {
+	if ok := polkadotReferenceRegex.MatchString(reference); !ok {
+		return fmt.Errorf("%w: invalid genesis hash: %s", ErrReferenceInvalid, reference)
+	}
+
+	return nil
+}
+
+func (polkadotNamespace) ValidateAddress(chainID ChainID, address string) error {
+	if _, _, err := polkadotAddress(chainID, address); err != nil {
+		return fmt.Errorf("%w: %s", ErrAddressInvalid, err)
+	}
+
+	return nil
+}
+
+func (n polkadotNamespace) NormalizeAddress(chainID ChainID, address string) (string, error) {
+	if err := n.ValidateAddress(chainID, address); err != nil {
+		return "", err
+	}
+
+	return address, nil
+}
+
+func (polkadotNamespace) ValidateAsset(a AssetID) error {
+	return nil
+}
+
+func polkadotAddress(chainID ChainID, address string) (uint16, []byte, error) {
+	prefix, key, err := SS58Decode(address)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid ss58 address: %s", address)
+	}
+
+	if expected, ok := SS58Prefix(chainID.Reference); ok && prefix != expected {
+		return 0, nil, fmt.Errorf("ss58 prefix %d does not match chain prefix %d", prefix, expected)
+	}
+
+	return prefix, key, nil
+}
+
+// SS58Decode returns the network prefix and public key of an SS58 address.
+// Only 32 byte (sr25519, ed25519) and 33 byte (ecdsa) keys are accepted.
+func SS58Decode(address string) (uint16, []byte, error) {
+	b, err := base58Decode(address)
+	if err != nil || len(b) < 1 {
+		return 0, nil, errSS58Invalid
+	}
+
+	var prefix uint16
+	var prefixLen int
+	switch {
+	case b[0] < 64:
+		prefix, prefixLen = uint16(b[0]), 1
+	case b[0] < 128 && len(b) > 1:
+		lower := uint16(b[0]&0x3f)<<2 | uint16(b[1])>>6
+		upper := uint16(b[1] & 0x3f)
+		prefix, prefixLen = lower|upper<<8, 2
+	default:
+		return 0, nil, errSS58Invalid
+	}
+
+	keyLen := len(b) - prefixLen - 2
+	if keyLen != 32 && keyLen != 33 {
+		return 0, nil, errSS58Invalid
+	}
+
+	sum := ss58Checksum(b[:len(b)-2])
+	if !bytes.Equal(sum[:2], b[len(b)-2:]) {
+		return 0, nil, errSS58Invalid
+	}
+
+	return prefix, b[prefixLen : prefixLen+keyLen], nil
+}
+
+func SS58Encode(prefix uint16, key []byte) (string, error) {
+	if prefix > 16383 {
+		return "", fmt.Errorf("invalid ss58 prefix: %d", prefix)
+	}
+
+	if len(key) != 32 && len(key) != 33 {
+		return "", fmt.Errorf("invalid ss58 public key length: %d", len(key))
+	}
+
+	var b []byte
+	if prefix < 64 {
+		b = append(b, byte(prefix))
+	} else {
+		b = append(b, byte((prefix&0xfc)>>2|0x40), byte(prefix>>8|(prefix&0x03)<<6))
+	}
+	b = append(b, key...)
+
+	sum := ss58Checksum(b)
+	return base58Encode(append(b, sum[:2]...)), nil
+}
+
+func ss58Checksum(b []byte) [64]byte {
+	return blake2b.Sum512(append([]byte("SS58PRE"), b...))
+}
+
+type PolkadotAccountID struct {
+	AccountID
+}
+
+func NewPolkadotAccountID(chainID ChainID, address string) (PolkadotAccountID, error) {
+	aID := PolkadotAccountID{AccountID{chainID, address}}
+	if err := aID.Validate(); err != nil {
+		return PolkadotAccountID{}, err
+	}
+
+	return aID, nil
+}
+
+func UnsafePolkadotAccountID(chainID ChainID, address string) PolkadotAccountID {
+	return PolkadotAccountID{AccountID{chainID, address}}
+}
+
+func (a PolkadotAccountID) Validate() error {
+	if _, _, err := polkadotAddress(a.ChainID, a.Address); err != nil {
+		return err
+	}
+
+	if a.ChainID.Namespace != "polkadot" {
+		return fmt.Errorf("invalid chain namespace: %s", a.ChainID.Namespace)
+	}
+
+	return a.AccountID.Validate()
+}
+
+func (a *PolkadotAccountID) Parse(s string) error {
+	return parseTyped(s, a.AccountID.Parse, a)
+}
+
+func (a *PolkadotAccountID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *PolkadotAccountID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *PolkadotAccountID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AccountID.UnmarshalJSON, a)
+}
+
+func (a *PolkadotAccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *PolkadotAccountID) Scan(src interface{}) error {
+	return scanParse(AccountIDKind, src, a.Parse)
+}
+
+func (a *PolkadotAccountID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AccountIDKind, v, a.Parse)
+}
+
+func (a PolkadotAccountID) Prefix() uint16 {
+	prefix, _, _ := polkadotAddress(a.ChainID, a.Address)
+	return prefix
+}
+
+func (a PolkadotAccountID) PublicKey() []byte {
+	_, key, _ := polkadotAddress(a.ChainID, a.Address)
+	return key
+}
+
+// Reencode returns the account address encoded under a different SS58 prefix.
+func (a PolkadotAccountID) Reencode(prefix uint16) (string, error) {
+	if err := a.Validate(); err != nil {
+		return "", err
+	}
+
+	return SS58Encode(prefix, a.PublicKey())
+}
+
+// ForChain returns the same account on another chain, using the SS58 prefix
+// registered for it.
+func (a PolkadotAccountID) ForChain(chainID ChainID) (PolkadotAccountID, error) {
+	prefix, ok := SS58Prefix(chainID.Reference)
+	if !ok {
+		return PolkadotAccountID{}, fmt.Errorf("unknown ss58 prefix for chain: %s", chainID.Reference)
+	}
+
+	address, err := a.Reencode(prefix)
+	if err != nil {
+		return PolkadotAccountID{}, err
+	}
+
+	return NewPolkadotAccountID(chainID, address)
+}
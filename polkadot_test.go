@@ -0,0 +1,162 @@
+package caip
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestPolkadotAccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id     string
+		prefix uint16
+	}{{
+		// Polkadot
+		id:     "polkadot:91b171bb158e2d3848fa23a9f1c25182:15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		prefix: 0,
+	}, {
+		// Kusama
+		id:     "polkadot:b0a8d493285c2df73290dfb7e61f870f:HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		prefix: 2,
+	}, {
+		// Westend
+		id:     "polkadot:e143f23803ac50e8f6f8e62695d1ce9e:5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		prefix: 42,
+	}} {
+		a := PolkadotAccountID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse account id: %v", err)
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		if _, err := NewPolkadotAccountID(a.ChainID, a.Address); err != nil {
+			t.Errorf("Failed to create account id from address")
+		}
+
+		if a.Prefix() != tc.prefix {
+			t.Errorf("Prefix invalid: %d", a.Prefix())
+		}
+
+		if hex.EncodeToString(a.PublicKey()) != "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" {
+			t.Errorf("Public key invalid: %x", a.PublicKey())
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = PolkadotAccountID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled account id invalid")
+		}
+	}
+}
+
+func TestInvalidPolkadotAccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		err error
+	}{{
+		// Bad checksum
+		id:  "polkadot:91b171bb158e2d3848fa23a9f1c25182:15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp6",
+		err: fmt.Errorf("invalid ss58 address: %s", "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp6"),
+	}, {
+		// Kusama address on Polkadot
+		id:  "polkadot:91b171bb158e2d3848fa23a9f1c25182:HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		err: fmt.Errorf("ss58 prefix 2 does not match chain prefix 0"),
+	}, {
+		id:  "polkadot:b0a8d493285c2df73290dfb7e61f870f:5hmuyxw9xdgbpptgypokw4thfyoe3ryenebr381z9iaegmfy",
+		err: fmt.Errorf("invalid ss58 address: %s", "5hmuyxw9xdgbpptgypokw4thfyoe3ryenebr381z9iaegmfy"),
+	}, {
+		id:  "chainstd:8c3444cf8970a9e41a706fab93e7a6c4:5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		err: fmt.Errorf("invalid chain namespace: %s", "chainstd"),
+	}} {
+		a := PolkadotAccountID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse account id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		_, err := NewPolkadotAccountID(a.ChainID, a.Address)
+		if err == nil {
+			t.Fatalf("Create account id should error")
+		}
+
+		if err.Error() != tc.err.Error() {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
+
+func TestPolkadotAccountIDForChain(t *testing.T) {
+	a := PolkadotAccountID{}
+	a.ParseX("polkadot:91b171bb158e2d3848fa23a9f1c25182:15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5")
+
+	generic, err := a.Reencode(42)
+	if err != nil {
+		t.Fatalf("Failed to reencode address: %v", err)
+	}
+
+	if generic != "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY" {
+		t.Errorf("Reencoded address invalid: %s", generic)
+	}
+
+	astar, err := a.ForChain(ChainID{"polkadot", "9eb76c5184c4ab8679d2d5d819fdf90b"})
+	if err != nil {
+		t.Fatalf("Failed to move account to chain: %v", err)
+	}
+
+	if astar.Address != "ajYMsCKsEAhEvHpeA4XqsfiA9v1CdzZPrCfS6pEfeGHW9j8" {
+		t.Errorf("Account address invalid: %s", astar.Address)
+	}
+
+	if _, err := a.ForChain(ChainID{"polkadot", "00000000000000000000000000000000"}); err == nil {
+		t.Errorf("Moving account to unknown chain should error")
+	}
+
+	// Two byte prefix
+	s, err := SS58Encode(1284, a.PublicKey())
+	if err != nil {
+		t.Fatalf("Failed to encode address: %v", err)
+	}
+
+	prefix, key, err := SS58Decode(s)
+	if err != nil || prefix != 1284 || hex.EncodeToString(key) != hex.EncodeToString(a.PublicKey()) {
+		t.Errorf("Two byte prefix round trip invalid: %d %x %v", prefix, key, err)
+	}
+}
+
+func TestSS58RoundTrip(t *testing.T) {
+	key, _ := hex.DecodeString("d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	for _, prefix := range []uint16{0, 2, 63, 64, 100, 255, 256, 1284, 16383} {
+		s, err := SS58Encode(prefix, key)
+		if err != nil {
+			t.Fatalf("Failed to encode address with prefix %d: %v", prefix, err)
+		}
+
+		p, k, err := SS58Decode(s)
+		if err != nil {
+			t.Fatalf("Failed to decode address %s: %v", s, err)
+		}
+
+		if p != prefix || hex.EncodeToString(k) != hex.EncodeToString(key) {
+			t.Errorf("Round trip of prefix %d invalid: %d %x", prefix, p, k)
+		}
+	}
+
+	if _, err := SS58Encode(16384, key); err == nil {
+		t.Errorf("Encoding prefix 16384 should error")
+	}
+}
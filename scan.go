@@ -0,0 +1,108 @@
+package caip
+
+import (
+	"unsafe"
+)
+
+// Character classes of the CAIP grammar.
+var (
+	namespaceChars      = charClass("-abcdefghijklmnopqrstuvwxyz0123456789")
+	chainReferenceChars = charClass("-_abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	addressChars        = charClass("-.%abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	legacyAddressChars  = charClass("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+)
+
+func charClass(chars string) *[256]bool {
+	var class [256]bool
+	for i := 0; i < len(chars); i++ {
+		class[chars[i]] = true
+	}
+	return &class
+}
+
+// grammar records which components of an identifier match the CAIP grammar.
+type grammar uint8
+
+const (
+	chainNamespaceOK grammar = 1 << iota
+	chainReferenceOK
+	accountAddressOK
+	assetNamespaceOK
+	assetReferenceOK
+	tokenIDOK
+)
+
+func (g grammar) set(flag grammar, ok bool) grammar {
+	if ok {
+		return g | flag
+	}
+	return g
+}
+
+// component scans s from i up to the next sep1 or sep2, or the end of s. It
+// returns the end of the component, whether it has between min and max
+// characters, all in class, and whether a separator was found.
+func component(s string, i int, sep1, sep2 byte, class *[256]bool, min, max int) (end int, ok bool, found bool) {
+	ok = true
+	for end = i; end < len(s); end++ {
+		c := s[end]
+		if c == sep1 || c == sep2 {
+			found = true
+			break
+		}
+		if !class[c] {
+			ok = false
+		}
+	}
+
+	n := end - i
+	return end, ok && n >= min && n <= max, found
+}
+
+// match reports whether s has between min and max characters, all in class.
+func match(s string, class *[256]bool, min, max int) bool {
+	if len(s) < min || len(s) > max {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !class[s[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+func validChainNamespace(s string) bool {
+	return match(s, namespaceChars, 3, 8)
+}
+
+func validChainReference(s string) bool {
+	return match(s, chainReferenceChars, 1, 32)
+}
+
+func validAccountAddress(s string) bool {
+	return match(s, addressChars, 1, 128)
+}
+
+// validLegacyAccountAddress checks the original CAIP-10 address grammar, see
+// LegacyAccountID.
+func validLegacyAccountAddress(s string) bool {
+	return match(s, legacyAddressChars, 1, 64)
+}
+
+func validAssetNamespace(s string) bool {
+	return match(s, namespaceChars, 3, 8)
+}
+
+func validAssetReference(s string) bool {
+	return match(s, addressChars, 1, 128)
+}
+
+func validTokenID(s string) bool {
+	return match(s, addressChars, 1, 78)
+}
+
+// unsafeString returns b as a string without copying it.
+func unsafeString(b []byte) string {
+	return *(*string)(unsafe.Pointer(&b))
+}
@@ -0,0 +1,264 @@
+package caip
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// The grammar as regular expressions, which the scanner must match exactly.
+var (
+	chainNamespaceRegex = regexp.MustCompile("^[-a-z0-9]{3,8}$")
+	chainReferenceRegex = regexp.MustCompile("^[-_a-zA-Z0-9]{1,32}$")
+	accountRegex        = regexp.MustCompile("^[-.%a-zA-Z0-9]{1,128}$")
+	legacyAccountRegex  = regexp.MustCompile("^[a-zA-Z0-9]{1,64}$")
+	assetNamespaceRegex = regexp.MustCompile("^[-a-z0-9]{3,8}$")
+	assetReferenceRegex = regexp.MustCompile("^[-.%a-zA-Z0-9]{1,128}$")
+	tokenIDRegex        = regexp.MustCompile("^[-.%a-zA-Z0-9]{1,78}$")
+)
+
+// randomIdentifier mutates a valid identifier or builds one from characters
+// that are significant to the grammar.
+func randomIdentifier(r *rand.Rand) string {
+	const alphabet = "abz09AZ-_.%:/:/ \n\x00\xff"
+
+	valid := []string{
+		"eip155:1",
+		"eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+		"eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769",
+		"cosmos:cosmoshub-3/slip44:118",
+		"solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+		"chainstd:8c3444cf8970a9e41a706fab93e7a6c4:6d9b0b4b9994e8a6afbd3dc3ed983cd51c755afb27cd1dc7825ef59c134a39f7",
+	}
+
+	if r.Intn(4) == 0 {
+		b := make([]byte, r.Intn(140))
+		for i := range b {
+			b[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+
+	b := []byte(valid[r.Intn(len(valid))])
+	for n := r.Intn(4); n > 0 && len(b) > 0; n-- {
+		i := r.Intn(len(b))
+		switch r.Intn(3) {
+		case 0:
+			b[i] = alphabet[r.Intn(len(alphabet))]
+		case 1:
+			b = append(b[:i], b[i+1:]...)
+		case 2:
+			b = append(b[:i], append([]byte{alphabet[r.Intn(len(alphabet))]}, b[i:]...)...)
+		}
+	}
+	return string(b)
+}
+
+func TestGrammarMatchesRegex(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		s := randomIdentifier(r)
+		// Components are at most as long as the whole identifier
+		for _, part := range append(strings.FieldsFunc(s, func(c rune) bool { return c == ':' || c == '/' }), s) {
+			for _, tc := range []struct {
+				name  string
+				regex *regexp.Regexp
+				match func(string) bool
+			}{
+				{"chain namespace", chainNamespaceRegex, validChainNamespace},
+				{"chain reference", chainReferenceRegex, validChainReference},
+				{"account address", accountRegex, validAccountAddress},
+				{"asset namespace", assetNamespaceRegex, validAssetNamespace},
+				{"asset reference", assetReferenceRegex, validAssetReference},
+				{"token id", tokenIDRegex, validTokenID},
+			} {
+				if tc.match(part) != tc.regex.MatchString(part) {
+					t.Fatalf("%s %q: expected %t", tc.name, part, tc.regex.MatchString(part))
+				}
+			}
+		}
+	}
+
+	for _, s := range []string{"abc", "a.b", "a%20", strings.Repeat("a", 64), strings.Repeat("a", 65), ""} {
+		if validLegacyAccountAddress(s) != legacyAccountRegex.MatchString(s) {
+			t.Errorf("legacy account address %q: expected %t", s, legacyAccountRegex.MatchString(s))
+		}
+	}
+}
+
+// The parsers before the scanner, splitting the identifier and validating its
+// components.
+func splitParseChainID(s string) (ChainID, error) {
+	split := strings.SplitN(s, ":", 2)
+	if len(split) != 2 {
+		return ChainID{}, malformedError(ChainIDKind, s)
+	}
+
+	c := ChainID{split[0], split[1]}
+	return c, c.Validate()
+}
+
+func splitParseAccountID(s string) (AccountID, error) {
+	split := strings.SplitN(s, ":", 3)
+	if len(split) != 3 {
+		return AccountID{}, malformedError(AccountIDKind, s)
+	}
+
+	c := AccountID{ChainID{split[0], split[1]}, split[2]}
+	return c, c.Validate()
+}
+
+func splitParseAsset(kind Kind, s string) (ChainID, string, string, bool) {
+	components := strings.SplitN(s, "/", 2)
+	if len(components) != 2 {
+		return ChainID{}, "", "", false
+	}
+
+	chain := strings.SplitN(components[0], ":", 2)
+	if len(chain) != 2 {
+		return ChainID{}, "", "", false
+	}
+
+	asset := strings.SplitN(components[1], ":", 2)
+	if len(asset) != 2 {
+		return ChainID{}, "", "", false
+	}
+
+	return ChainID{chain[0], chain[1]}, asset[0], asset[1], true
+}
+
+func splitParseAssetID(s string) (AssetID, error) {
+	chainID, namespace, reference, ok := splitParseAsset(AssetIDKind, s)
+	if !ok {
+		return AssetID{}, malformedError(AssetIDKind, s)
+	}
+
+	a := UnsafeAssetID(chainID, namespace, reference)
+	return a, a.Validate()
+}
+
+func splitParseAssetType(s string) (AssetType, error) {
+	chainID, namespace, reference, ok := splitParseAsset(AssetTypeKind, s)
+	if !ok {
+		return AssetType{}, malformedError(AssetTypeKind, s)
+	}
+
+	a := AssetType{chainID, namespace, reference}
+	return a, a.Validate()
+}
+
+func TestParseMatchesSplit(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 20000; i++ {
+		s := randomIdentifier(r)
+
+		for _, tc := range []struct {
+			parse func() (interface{}, error)
+			split func() (interface{}, error)
+		}{{
+			func() (interface{}, error) { var c ChainID; err := c.Parse(s); return c, err },
+			func() (interface{}, error) { return splitParseChainID(s) },
+		}, {
+			func() (interface{}, error) { var c AccountID; err := c.Parse(s); return c, err },
+			func() (interface{}, error) { return splitParseAccountID(s) },
+		}, {
+			func() (interface{}, error) { var a AssetID; err := a.Parse(s); return a, err },
+			func() (interface{}, error) { return splitParseAssetID(s) },
+		}, {
+			func() (interface{}, error) { var a AssetType; err := a.Parse(s); return a, err },
+			func() (interface{}, error) { return splitParseAssetType(s) },
+		}} {
+			v, err := tc.parse()
+			expected, expectedErr := tc.split()
+			if !reflect.DeepEqual(err, expectedErr) {
+				t.Fatalf("%q: expected error %v, got: %v", s, expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(v, expected) {
+				t.Fatalf("%q: expected %+v, got: %+v", s, expected, v)
+			}
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	b := []byte("eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769")
+
+	var a AssetID
+	if err := a.ParseBytes(b); err != nil {
+		t.Fatalf("Failed to parse bytes: %v", err)
+	}
+
+	if a.String() != string(b) || a.TokenID != "771769" {
+		t.Errorf("Parsed asset id invalid: %s", a)
+	}
+
+	var c AccountID
+	if err := c.ParseBytes([]byte("eip155:1")); err == nil {
+		t.Errorf("Parse bytes should error")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		a.ParseBytes(b)
+	}); allocs != 0 {
+		t.Errorf("expected no allocations, got: %v", allocs)
+	}
+}
+
+var (
+	benchmarkChainID   = "eip155:1"
+	benchmarkAccountID = "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb"
+	benchmarkAssetID   = "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769"
+)
+
+func BenchmarkParseChainID(b *testing.B) {
+	b.ReportAllocs()
+	var c ChainID
+	for i := 0; i < b.N; i++ {
+		if err := c.Parse(benchmarkChainID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseAccountID(b *testing.B) {
+	b.ReportAllocs()
+	var c AccountID
+	for i := 0; i < b.N; i++ {
+		if err := c.Parse(benchmarkAccountID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseAssetID(b *testing.B) {
+	b.ReportAllocs()
+	var a AssetID
+	for i := 0; i < b.N; i++ {
+		if err := a.Parse(benchmarkAssetID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseBytesAssetID(b *testing.B) {
+	b.ReportAllocs()
+	data := []byte(benchmarkAssetID)
+	var a AssetID
+	for i := 0; i < b.N; i++ {
+		if err := a.ParseBytes(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseAssetIDSplit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := splitParseAssetID(benchmarkAssetID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
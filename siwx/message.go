@@ -0,0 +1,334 @@
+// Package siwx implements CAIP-122 Sign-In-With-X messages, rendered in the
+// EIP-4361 text format.
+//
+// The text of a message is what wallets sign. Parse accepts exactly the text
+// String renders, so a parsed message can be verified against its signature:
+//
+//	m, err := siwx.Parse(text)
+//	if err != nil {
+//		return err
+//	}
+//	if err := m.Verify(signature); err != nil {
+//		return err
+//	}
+//	if err := m.ValidAt(time.Now()); err != nil {
+//		return err
+//	}
+package siwx
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidMessage   = errors.New("invalid sign-in message")
+	ErrExpired          = errors.New("sign-in message expired")
+	ErrNotYetValid      = errors.New("sign-in message not yet valid")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+var (
+	schemeRegex = regexp.MustCompile("^[a-zA-Z][-+.a-zA-Z0-9]*$")
+	nonceRegex  = regexp.MustCompile("^[a-zA-Z0-9]{8,}$")
+	headerRegex = regexp.MustCompile("^(?:([a-zA-Z][-+.a-zA-Z0-9]*)://)?(\\S+) wants you to sign in with your (.+) account:$")
+)
+
+// Message is a CAIP-122 sign-in message. Timestamps are kept in their RFC 3339
+// string form, as signed.
+type Message struct {
+	// Scheme is the optional URI scheme of the origin, e.g. "https"
+	Scheme string
+	// Domain is the RFC 3986 authority requesting the sign-in
+	Domain  string
+	Account caip.AccountID
+	// Statement is an optional human-readable line of text
+	Statement      string
+	URI            string
+	Version        string
+	Nonce          string
+	IssuedAt       string
+	ExpirationTime string
+	NotBefore      string
+	RequestID      string
+	Resources      []string
+}
+
+func (m Message) Validate() error {
+	if m.Scheme != "" && !schemeRegex.MatchString(m.Scheme) {
+		return fmt.Errorf("%w: invalid scheme: %s", ErrInvalidMessage, m.Scheme)
+	}
+
+	if !validAuthority(m.Domain) {
+		return fmt.Errorf("%w: invalid domain: %s", ErrInvalidMessage, m.Domain)
+	}
+
+	if err := m.Account.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+
+	if _, ok := LookupNamespace(m.Account.ChainID.Namespace); !ok {
+		return fmt.Errorf("%w: unsupported namespace: %s", ErrInvalidMessage, m.Account.ChainID.Namespace)
+	}
+
+	if strings.ContainsAny(m.Statement, "\r\n") {
+		return fmt.Errorf("%w: statement must be a single line", ErrInvalidMessage)
+	}
+
+	if !validURI(m.URI) {
+		return fmt.Errorf("%w: invalid uri: %s", ErrInvalidMessage, m.URI)
+	}
+
+	if m.Version != "1" {
+		return fmt.Errorf("%w: unsupported version: %s", ErrInvalidMessage, m.Version)
+	}
+
+	if !nonceRegex.MatchString(m.Nonce) {
+		return fmt.Errorf("%w: invalid nonce: %s", ErrInvalidMessage, m.Nonce)
+	}
+
+	for _, t := range []struct {
+		name     string
+		value    string
+		optional bool
+	}{
+		{"issued at", m.IssuedAt, false},
+		{"expiration time", m.ExpirationTime, true},
+		{"not before", m.NotBefore, true},
+	} {
+		if t.value == "" && t.optional {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, t.value); err != nil {
+			return fmt.Errorf("%w: invalid %s: %s", ErrInvalidMessage, t.name, t.value)
+		}
+	}
+
+	if strings.ContainsAny(m.RequestID, " \r\n") {
+		return fmt.Errorf("%w: invalid request id: %s", ErrInvalidMessage, m.RequestID)
+	}
+
+	for _, r := range m.Resources {
+		if !validURI(r) {
+			return fmt.Errorf("%w: invalid resource: %s", ErrInvalidMessage, r)
+		}
+	}
+
+	return nil
+}
+
+func validAuthority(s string) bool {
+	if s == "" || strings.ContainsAny(s, "/?# \r\n") {
+		return false
+	}
+
+	u, err := url.Parse("//" + s)
+	return err == nil && u.Host != ""
+}
+
+// validURI reports whether s is an absolute RFC 3986 URI.
+func validURI(s string) bool {
+	if strings.ContainsAny(s, " \r\n") {
+		return false
+	}
+
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != ""
+}
+
+// ValidAt checks the expiration time and not before time of the message.
+func (m Message) ValidAt(now time.Time) error {
+	if m.ExpirationTime != "" {
+		t, err := time.Parse(time.RFC3339, m.ExpirationTime)
+		if err != nil {
+			return fmt.Errorf("%w: invalid expiration time: %s", ErrInvalidMessage, m.ExpirationTime)
+		}
+		if !now.Before(t) {
+			return fmt.Errorf("%w at %s", ErrExpired, m.ExpirationTime)
+		}
+	}
+
+	if m.NotBefore != "" {
+		t, err := time.Parse(time.RFC3339, m.NotBefore)
+		if err != nil {
+			return fmt.Errorf("%w: invalid not before: %s", ErrInvalidMessage, m.NotBefore)
+		}
+		if now.Before(t) {
+			return fmt.Errorf("%w until %s", ErrNotYetValid, m.NotBefore)
+		}
+	}
+
+	return nil
+}
+
+// Verify checks that signature is a signature of the message text by its
+// account, using the verifier registered for the account's namespace.
+func (m Message) Verify(signature []byte) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	ns, _ := LookupNamespace(m.Account.ChainID.Namespace)
+	return ns.Verify(m.Account, m.String(), signature)
+}
+
+// String renders the message in the EIP-4361 text format with the chain name
+// of the account's namespace. The message should be valid.
+func (m Message) String() string {
+	chainName := m.Account.ChainID.Namespace
+	if ns, ok := LookupNamespace(chainName); ok {
+		chainName = ns.ChainName()
+	}
+
+	var b strings.Builder
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	fmt.Fprintf(&b, "%s wants you to sign in with your %s account:\n", m.Domain, chainName)
+	b.WriteString(m.Account.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "URI: %s\n", m.URI)
+	fmt.Fprintf(&b, "Version: %s\n", m.Version)
+	fmt.Fprintf(&b, "Chain ID: %s\n", m.Account.ChainID.Reference)
+	fmt.Fprintf(&b, "Nonce: %s\n", m.Nonce)
+	fmt.Fprintf(&b, "Issued At: %s", m.IssuedAt)
+	if m.ExpirationTime != "" {
+		fmt.Fprintf(&b, "\nExpiration Time: %s", m.ExpirationTime)
+	}
+	if m.NotBefore != "" {
+		fmt.Fprintf(&b, "\nNot Before: %s", m.NotBefore)
+	}
+	if m.RequestID != "" {
+		fmt.Fprintf(&b, "\nRequest ID: %s", m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, r := range m.Resources {
+			b.WriteString("\n- " + r)
+		}
+	}
+
+	return b.String()
+}
+
+// Parse parses and validates a message in the EIP-4361 text format. Fields
+// must appear in order and the text must not have trailing lines, so that
+// String returns the parsed text exactly.
+func Parse(s string) (Message, error) {
+	lines := strings.Split(s, "\n")
+	p := parser{lines: lines}
+
+	header := headerRegex.FindStringSubmatch(p.next())
+	if header == nil {
+		return Message{}, p.errorf("invalid header")
+	}
+
+	namespace, ok := lookupChainName(header[3])
+	if !ok {
+		return Message{}, p.errorf("unsupported chain: %s", header[3])
+	}
+
+	m := Message{Scheme: header[1], Domain: header[2]}
+	address := p.next()
+
+	if p.next() != "" {
+		return Message{}, p.errorf("expected empty line")
+	}
+
+	if line := p.next(); line != "" {
+		m.Statement = line
+		if p.next() != "" {
+			return Message{}, p.errorf("expected empty line")
+		}
+	}
+
+	m.URI = p.field("URI", true)
+	m.Version = p.field("Version", true)
+	reference := p.field("Chain ID", true)
+	m.Nonce = p.field("Nonce", true)
+	m.IssuedAt = p.field("Issued At", true)
+	m.ExpirationTime = p.field("Expiration Time", false)
+	m.NotBefore = p.field("Not Before", false)
+	m.RequestID = p.field("Request ID", false)
+
+	if p.err == nil && p.i < len(lines) && lines[p.i] == "Resources:" {
+		p.i++
+		for p.i < len(lines) && strings.HasPrefix(lines[p.i], "- ") {
+			m.Resources = append(m.Resources, strings.TrimPrefix(p.next(), "- "))
+		}
+		if len(m.Resources) == 0 {
+			return Message{}, p.errorf("expected resources")
+		}
+	}
+
+	if p.err != nil {
+		return Message{}, p.err
+	}
+
+	if p.i < len(lines) {
+		return Message{}, fmt.Errorf("%w: unexpected line %d: %s", ErrInvalidMessage, p.i+1, lines[p.i])
+	}
+
+	m.Account = caip.AccountID{ChainID: caip.ChainID{Namespace: namespace, Reference: reference}, Address: address}
+	if err := m.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	// EIP-4361 requires EIP-55 checksummed addresses
+	if namespace == "eip155" && common.HexToAddress(address).Hex() != address {
+		return Message{}, fmt.Errorf("%w: address is not checksummed: %s", ErrInvalidMessage, address)
+	}
+
+	return m, nil
+}
+
+// parser reads the lines of a message, recording the first error.
+type parser struct {
+	lines []string
+	i     int
+	err   error
+}
+
+func (p *parser) next() string {
+	if p.i >= len(p.lines) {
+		p.i++
+		return ""
+	}
+	line := p.lines[p.i]
+	p.i++
+	return line
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidMessage, p.i, fmt.Sprintf(format, args...))
+}
+
+// field reads the "<name>: <value>" line, which may be missing if it is
+// optional.
+func (p *parser) field(name string, required bool) string {
+	if p.err != nil {
+		return ""
+	}
+
+	prefix := name + ": "
+	if p.i < len(p.lines) && strings.HasPrefix(p.lines[p.i], prefix) {
+		return strings.TrimPrefix(p.next(), prefix)
+	}
+
+	if required {
+		p.i++
+		p.err = p.errorf("expected %s", name)
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package siwx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	caip "github.com/ChainAgnostic/go-caip"
+)
+
+// From EIP-4361
+const exampleMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		message string
+	}{{
+		name:    "eip-4361",
+		message: exampleMessage,
+	}, {
+		name: "all fields",
+		message: `https://example.com:8080 wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+Sign in.
+
+URI: https://example.com/login
+Version: 1
+Chain ID: 137
+Nonce: abcdEFGH1234
+Issued At: 2021-09-30T16:25:24.000Z
+Expiration Time: 2021-10-30T16:25:24+02:00
+Not Before: 2021-09-30T16:25:24Z
+Request ID: some-request
+Resources:
+- https://example.com/a`,
+	}, {
+		name: "no statement",
+		message: `example.com wants you to sign in with your Solana account:
+GwHH8ciFhR8vejWCqmg8FWZUCNtubPY2esALvy5tBvji
+
+
+URI: https://example.com
+Version: 1
+Chain ID: 5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp
+Nonce: 12345678
+Issued At: 2021-09-30T16:25:24Z`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Parse(tc.message)
+			if err != nil {
+				t.Fatalf("Failed to parse message: %v", err)
+			}
+
+			if m.String() != tc.message {
+				t.Errorf("Rendered message differs:\n%s", m.String())
+			}
+		})
+	}
+
+	m, _ := Parse(exampleMessage)
+	if m.Account.String() != "eip155:1:0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2" || m.Domain != "service.invalid" || m.Nonce != "32891756" || len(m.Resources) != 2 {
+		t.Errorf("Parsed message invalid: %+v", m)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		message string
+	}{
+		{"empty", ""},
+		{"header", strings.Replace(exampleMessage, "wants you", "asks you", 1)},
+		{"chain", strings.Replace(exampleMessage, "Ethereum", "Dogecoin", 1)},
+		{"domain", strings.Replace(exampleMessage, "service.invalid wants", "service.invalid/ wants", 1)},
+		{"address", strings.Replace(exampleMessage, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc", 1)},
+		{"lowercase address", strings.Replace(exampleMessage, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", 1)},
+		{"missing empty line", strings.Replace(exampleMessage, "tos\n\n", "tos\n", 1)},
+		{"uri", strings.Replace(exampleMessage, "URI: https://service.invalid/login", "URI: /login", 1)},
+		{"version", strings.Replace(exampleMessage, "Version: 1", "Version: 2", 1)},
+		{"missing version", strings.Replace(exampleMessage, "Version: 1\n", "", 1)},
+		{"chain id", strings.Replace(exampleMessage, "Chain ID: 1", "Chain ID: x", 1)},
+		{"nonce", strings.Replace(exampleMessage, "32891756", "1234567", 1)},
+		{"issued at", strings.Replace(exampleMessage, "2021-09-30T16:25:24Z", "2021-09-30", 1)},
+		{"order", strings.Replace(exampleMessage, "Version: 1\nChain ID: 1", "Chain ID: 1\nVersion: 1", 1)},
+		{"resource", strings.Replace(exampleMessage, "- https://example.com/my-web2-claim.json", "- my-web2-claim.json", 1)},
+		{"empty resources", exampleMessage[:strings.Index(exampleMessage, "\n- ")]},
+		{"trailing newline", exampleMessage + "\n"},
+		{"trailing line", exampleMessage + "\nRequest ID: 1"},
+		{"crlf", strings.ReplaceAll(exampleMessage, "\n", "\r\n")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(tc.message); !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("Parse should fail with invalid message, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	m, _ := Parse(exampleMessage)
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Message should be valid: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(m *Message)
+	}{
+		{"scheme", func(m *Message) { m.Scheme = "1http" }},
+		{"domain", func(m *Message) { m.Domain = "" }},
+		{"namespace", func(m *Message) {
+			m.Account = caip.UnsafeAccountID(caip.UnsafeChainID("cosmos", "cosmoshub-4"), "cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0")
+		}},
+		{"statement", func(m *Message) { m.Statement = "a\nb" }},
+		{"request id", func(m *Message) { m.RequestID = "a b" }},
+		{"expiration time", func(m *Message) { m.ExpirationTime = "tomorrow" }},
+		{"not before", func(m *Message) { m.NotBefore = "2021-09-30 16:25:24" }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, _ := Parse(exampleMessage)
+			tc.modify(&m)
+			if err := m.Validate(); !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("Validate should fail with invalid message, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidAt(t *testing.T) {
+	m, _ := Parse(exampleMessage)
+	m.NotBefore = "2021-10-01T00:00:00Z"
+	m.ExpirationTime = "2021-10-02T00:00:00Z"
+
+	for _, tc := range []struct {
+		now time.Time
+		err error
+	}{
+		{time.Date(2021, 9, 30, 0, 0, 0, 0, time.UTC), ErrNotYetValid},
+		{time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), nil},
+		{time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC), nil},
+		{time.Date(2021, 10, 2, 0, 0, 0, 0, time.UTC), ErrExpired},
+	} {
+		if err := m.ValidAt(tc.now); !errors.Is(err, tc.err) {
+			t.Errorf("ValidAt(%s): expected %v, got %v", tc.now, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package siwx
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Namespace holds the CAIP-122 profile of a CAIP-2 namespace.
+type Namespace interface {
+	// ChainName is the name of the chain in the message header, e.g.
+	// "Ethereum".
+	ChainName() string
+	// Verify checks that signature is a signature of message by account.
+	Verify(account caip.AccountID, message string, signature []byte) error
+}
+
+var (
+	namespacesMu sync.RWMutex
+	namespaces   = map[string]Namespace{}
+)
+
+func init() {
+	RegisterNamespace("eip155", eip155Namespace{})
+	RegisterNamespace("solana", solanaNamespace{})
+}
+
+// RegisterNamespace makes ns the profile for the given chain namespace,
+// replacing any previously registered profile including the built-in ones.
+func RegisterNamespace(name string, ns Namespace) {
+	if ns == nil {
+		panic("siwx: RegisterNamespace namespace is nil")
+	}
+
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+	namespaces[name] = ns
+}
+
+func UnregisterNamespace(name string) {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+	delete(namespaces, name)
+}
+
+func LookupNamespace(name string) (Namespace, bool) {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+	ns, ok := namespaces[name]
+	return ns, ok
+}
+
+// lookupChainName finds the namespace whose profile has the chain name, in
+// the order of the namespace names.
+func lookupChainName(chainName string) (string, bool) {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if namespaces[name].ChainName() == chainName {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+type eip155Namespace struct{}
+
+func (eip155Namespace) ChainName() string {
+	return "Ethereum"
+}
+
+// Verify recovers the signer of an EIP-191 personal_sign signature. Contract
+// wallets (EIP-1271) need an RPC connection and are not supported.
+func (eip155Namespace) Verify(account caip.AccountID, message string, signature []byte) error {
+	signer, err := RecoverPersonalSign(message, signature)
+	if err != nil {
+		return err
+	}
+
+	if signer != common.HexToAddress(account.Address) {
+		return fmt.Errorf("%w: signed by %s", ErrInvalidSignature, signer.Hex())
+	}
+
+	return nil
+}
+
+// PersonalSignHash returns the EIP-191 hash of message signed by
+// personal_sign.
+func PersonalSignHash(message string) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))
+	return crypto.Keccak256([]byte(prefix), []byte(message))
+}
+
+// RecoverPersonalSign returns the address that signed message with
+// personal_sign. The recovery id of the 65-byte signature may be 0/1 or
+// 27/28.
+func RecoverPersonalSign(message string, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, crypto.SignatureLength, len(signature))
+	}
+
+	sig := append([]byte(nil), signature...)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(PersonalSignHash(message), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+type solanaNamespace struct{}
+
+func (solanaNamespace) ChainName() string {
+	return "Solana"
+}
+
+// Verify checks an Ed25519 signature by the account's public key.
+func (solanaNamespace) Verify(account caip.AccountID, message string, signature []byte) error {
+	key := caip.SolanaAccountID{AccountID: account}.PublicKey()
+	if len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid solana address: %s", ErrInvalidSignature, account.Address)
+	}
+
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(key, []byte(message), signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
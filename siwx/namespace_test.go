@@ -0,0 +1,116 @@
+package siwx
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	caip "github.com/ChainAgnostic/go-caip"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifyEIP155(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := Parse(exampleMessage)
+	m.Account.Address = crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	sig, err := crypto.Sign(PersonalSignHash(m.String()), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Verify(sig); err != nil {
+		t.Errorf("Failed to verify signature with recovery id 0/1: %v", err)
+	}
+
+	// wallets return recovery ids of 27/28
+	sig[64] += 27
+	if err := m.Verify(sig); err != nil {
+		t.Errorf("Failed to verify signature with recovery id 27/28: %v", err)
+	}
+
+	m.Nonce = "87654321"
+	if err := m.Verify(sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify of modified message should fail, got: %v", err)
+	}
+
+	if err := m.Verify(sig[:64]); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify of short signature should fail, got: %v", err)
+	}
+}
+
+func TestPersonalSignHash(t *testing.T) {
+	// ethers.utils.hashMessage("hello world")
+	if hash := hex.EncodeToString(PersonalSignHash("hello world")); hash != "d9eba16ed0ecae432b71fe008c98cc872bb4cc214d3220a36f365326cf807d68" {
+		t.Errorf("Hash invalid: %s", hash)
+	}
+}
+
+func TestVerifySolana(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+	m := Message{
+		Domain:   "example.com",
+		Account:  caip.UnsafeAccountID(caip.UnsafeChainID("solana", "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"), "4zvwRjXUKGfvwnParsHAS3HuSVzV5cA4McphgmoCtajS"),
+		URI:      "https://example.com",
+		Version:  "1",
+		Nonce:    "12345678",
+		IssuedAt: "2021-09-30T16:25:24Z",
+	}
+
+	sig := ed25519.Sign(priv, []byte(m.String()))
+	if err := m.Verify(sig); err != nil {
+		t.Errorf("Failed to verify signature: %v", err)
+	}
+
+	sig[0] ^= 1
+	if err := m.Verify(sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify of invalid signature should fail, got: %v", err)
+	}
+}
+
+type testNamespace struct{}
+
+func (testNamespace) ChainName() string {
+	return "Test"
+}
+
+func (testNamespace) Verify(account caip.AccountID, message string, signature []byte) error {
+	if string(signature) != account.Address+message {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func TestRegisterNamespace(t *testing.T) {
+	RegisterNamespace("test", testNamespace{})
+	defer UnregisterNamespace("test")
+
+	const message = `example.com wants you to sign in with your Test account:
+abc
+
+
+URI: https://example.com
+Version: 1
+Chain ID: 1
+Nonce: 12345678
+Issued At: 2021-09-30T16:25:24Z`
+
+	m, err := Parse(message)
+	if err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+
+	if m.Account.String() != "test:1:abc" {
+		t.Errorf("Account invalid: %s", m.Account)
+	}
+
+	if err := m.Verify([]byte("abc" + message)); err != nil {
+		t.Errorf("Failed to verify signature: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package caip
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// Slip44Coin is a registered SLIP-0044 coin type.
+type Slip44Coin struct {
+	CoinType uint32
+	Symbol   string
+	Name     string
+	Decimals int
+	// Namespaces the coin type can be used with, empty for any namespace
+	Namespaces []string
+	// Chains the coin is the native currency of
+	Chains []ChainID
+}
+
+var (
+	//go:embed slip44.json
+	slip44Data []byte
+
+	slip44ReferenceRegex = regexp.MustCompile("^(0|[1-9][0-9]{0,9})$")
+	slip44Mu             sync.RWMutex
+	slip44Coins          = map[uint32]Slip44Coin{}
+	slip44Natives        = map[ChainID]uint32{}
+)
+
+func init() {
+	// Bad rows are skipped rather than failing every importer, the test of the
+	// embedded table reports them
+	coins, _ := parseSlip44Coins(slip44Data)
+	for _, coin := range coins {
+		RegisterSlip44Coin(coin)
+	}
+}
+
+// parseSlip44Coins parses a table of coin types, skipping and reporting rows
+// with invalid chain ids.
+func parseSlip44Coins(data []byte) ([]Slip44Coin, []error) {
+	var entries []struct {
+		CoinType   uint32   `json:"coin_type"`
+		Symbol     string   `json:"symbol"`
+		Name       string   `json:"name"`
+		Decimals   int      `json:"decimals"`
+		Namespaces []string `json:"namespaces"`
+		Chains     []string `json:"chains"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, []error{fmt.Errorf("loading slip44 coin types: %w", err)}
+	}
+
+	var coins []Slip44Coin
+	var errs []error
+entries:
+	for _, e := range entries {
+		coin := Slip44Coin{e.CoinType, e.Symbol, e.Name, e.Decimals, e.Namespaces, nil}
+		for _, s := range e.Chains {
+			c := ChainID{}
+			if err := c.Parse(s); err != nil {
+				errs = append(errs, fmt.Errorf("slip44 coin type %d: %w", e.CoinType, err))
+				continue entries
+			}
+			coin.Chains = append(coin.Chains, c)
+		}
+		coins = append(coins, coin)
+	}
+
+	return coins, errs
+}
+
+// RegisterSlip44Coin adds or replaces a coin type, the coin becomes the native
+// asset of each of its chains.
+func RegisterSlip44Coin(coin Slip44Coin) {
+	slip44Mu.Lock()
+	defer slip44Mu.Unlock()
+	slip44Coins[coin.CoinType] = coin
+	for _, c := range coin.Chains {
+		slip44Natives[c] = coin.CoinType
+	}
+}
+
+func LookupSlip44Coin(coinType uint32) (Slip44Coin, bool) {
+	slip44Mu.RLock()
+	defer slip44Mu.RUnlock()
+	coin, ok := slip44Coins[coinType]
+	return coin, ok
+}
+
+// NativeAsset returns the slip44 asset id of the native currency of a chain.
+func NativeAsset(chainID ChainID) (Slip44AssetID, error) {
+	slip44Mu.RLock()
+	coinType, ok := slip44Natives[chainID]
+	slip44Mu.RUnlock()
+	if !ok {
+		return Slip44AssetID{}, fmt.Errorf("unknown native asset for chain: %s", chainID)
+	}
+
+	return NewSlip44AssetID(chainID, "slip44", strconv.FormatUint(uint64(coinType), 10))
+}
+
+type Slip44AssetID struct {
+	AssetID
+}
+
+func NewSlip44AssetID(chainID ChainID, namespace, reference string) (Slip44AssetID, error) {
+	aID := Slip44AssetID{UnsafeAssetID(chainID, namespace, reference)}
+	if err := aID.Validate(); err != nil {
+		return Slip44AssetID{}, err
+	}
+
+	return aID, nil
+}
+
+func UnsafeSlip44AssetID(chainID ChainID, namespace, reference string) Slip44AssetID {
+	return Slip44AssetID{UnsafeAssetID(chainID, namespace, reference)}
+}
+
+func (a Slip44AssetID) Validate() error {
+	coinType, err := parseSlip44CoinType(a.Reference)
+	if err != nil {
+		return err
+	}
+
+	if a.AssetID.Namespace != "slip44" {
+		return fmt.Errorf("invalid asset namespace: %s", a.AssetID.Namespace)
+	}
+
+	if coin, ok := LookupSlip44Coin(coinType); ok && len(coin.Namespaces) > 0 {
+		found := false
+		for _, ns := range coin.Namespaces {
+			found = found || ns == a.ChainID.Namespace
+		}
+		if !found {
+			return fmt.Errorf("coin type %d is not valid for chain namespace: %s", coinType, a.ChainID.Namespace)
+		}
+	}
+
+	return a.AssetID.Validate()
+}
+
+func (a *Slip44AssetID) Parse(s string) error {
+	return parseTyped(s, a.AssetID.Parse, a)
+}
+
+func (a *Slip44AssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *Slip44AssetID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *Slip44AssetID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AssetID.UnmarshalJSON, a)
+}
+
+func (a *Slip44AssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *Slip44AssetID) Scan(src interface{}) error {
+	return scanParse(AssetIDKind, src, a.Parse)
+}
+
+func (a *Slip44AssetID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
+}
+
+func (a Slip44AssetID) CoinType() uint32 {
+	coinType, _ := parseSlip44CoinType(a.Reference)
+	return coinType
+}
+
+func (a Slip44AssetID) Coin() (Slip44Coin, bool) {
+	return LookupSlip44Coin(a.CoinType())
+}
+
+func parseSlip44CoinType(s string) (uint32, error) {
+	if ok := slip44ReferenceRegex.MatchString(s); !ok {
+		return 0, fmt.Errorf("invalid coin type: %s", s)
+	}
+
+	coinType, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coin type: %s", s)
+	}
+
+	return uint32(coinType), nil
+}
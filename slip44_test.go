@@ -0,0 +1,195 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSlip44AssetID(t *testing.T) {
+	for _, tc := range []struct {
+		id       string
+		symbol   string
+		decimals int
+	}{{
+		// Ether Token
+		id:       "eip155:1/slip44:60",
+		symbol:   "ETH",
+		decimals: 18,
+	}, {
+		// Bitcoin Token
+		id:       "bip122:000000000019d6689c085ae165831e93/slip44:0",
+		symbol:   "BTC",
+		decimals: 8,
+	}, {
+		// ATOM Token
+		id:       "cosmos:cosmoshub-3/slip44:118",
+		symbol:   "ATOM",
+		decimals: 6,
+	}, {
+		// Lisk Token
+		id:       "lip9:9ee11e9df416b18b/slip44:134",
+		symbol:   "LSK",
+		decimals: 8,
+	}} {
+		a := Slip44AssetID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse asset id")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize asset id to string")
+		}
+
+		if _, err := NewSlip44AssetID(a.ChainID, a.AssetID.Namespace, a.AssetID.Reference); err != nil {
+			t.Errorf("Failed to create asset id from coin type: %v", err)
+		}
+
+		coin, ok := a.Coin()
+		if !ok {
+			t.Fatalf("Coin type not found: %d", a.CoinType())
+		}
+
+		if coin.Symbol != tc.symbol || coin.Decimals != tc.decimals {
+			t.Errorf("Coin invalid: %+v", coin)
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = Slip44AssetID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled asset id invalid")
+		}
+	}
+}
+
+func TestInvalidSlip44AssetID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		err error
+	}{{
+		id:  "eip155:1/slip44:060",
+		err: fmt.Errorf("invalid coin type: %s", "060"),
+	}, {
+		id:  "eip155:1/slip44:4294967296",
+		err: fmt.Errorf("invalid coin type: %s", "4294967296"),
+	}, {
+		id:  "cosmos:cosmoshub-4/native:118",
+		err: fmt.Errorf("invalid asset namespace: %s", "native"),
+	}, {
+		// Bitcoin on Ethereum
+		id:  "eip155:1/slip44:0",
+		err: fmt.Errorf("coin type %d is not valid for chain namespace: %s", 0, "eip155"),
+	}} {
+		a := Slip44AssetID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse asset id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize asset id to string")
+		}
+
+		_, err := NewSlip44AssetID(a.ChainID, a.AssetID.Namespace, a.AssetID.Reference)
+		if err == nil {
+			t.Fatalf("Create asset id should error")
+		}
+
+		if err.Error() != tc.err.Error() {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
+
+func TestNativeAsset(t *testing.T) {
+	for _, tc := range []struct {
+		chainID ChainID
+		id      string
+	}{{
+		chainID: ChainID{"eip155", "1"},
+		id:      "eip155:1/slip44:60",
+	}, {
+		chainID: ChainID{"eip155", "137"},
+		id:      "eip155:137/slip44:966",
+	}, {
+		chainID: ChainID{"bip122", "000000000019d6689c085ae165831e93"},
+		id:      "bip122:000000000019d6689c085ae165831e93/slip44:0",
+	}, {
+		chainID: ChainID{"solana", "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"},
+		id:      "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/slip44:501",
+	}} {
+		a, err := NativeAsset(tc.chainID)
+		if err != nil {
+			t.Errorf("Failed to get native asset: %v", err)
+			continue
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Native asset invalid: %s", a)
+		}
+	}
+
+	if _, err := NativeAsset(ChainID{"eip155", "999999"}); err == nil {
+		t.Errorf("Native asset of unknown chain should error")
+	}
+}
+
+func TestRegisterSlip44Coin(t *testing.T) {
+	chainID := ChainID{"eip155", "31337"}
+	RegisterSlip44Coin(Slip44Coin{CoinType: 31337, Symbol: "TST", Decimals: 18, Namespaces: []string{"eip155"}, Chains: []ChainID{chainID}})
+	defer func() {
+		slip44Mu.Lock()
+		delete(slip44Coins, 31337)
+		delete(slip44Natives, chainID)
+		slip44Mu.Unlock()
+	}()
+
+	a, err := NativeAsset(chainID)
+	if err != nil {
+		t.Fatalf("Failed to get native asset: %v", err)
+	}
+
+	if coin, _ := a.Coin(); coin.Symbol != "TST" {
+		t.Errorf("Registered coin invalid: %+v", coin)
+	}
+
+	if _, err := NewSlip44AssetID(ChainID{"cosmos", "cosmoshub-4"}, "slip44", "31337"); err == nil {
+		t.Errorf("Registered coin should not be valid for cosmos")
+	}
+}
+
+func TestSlip44Table(t *testing.T) {
+	coins, errs := parseSlip44Coins(slip44Data)
+	for _, err := range errs {
+		t.Errorf("Invalid slip44 table entry: %v", err)
+	}
+
+	if len(coins) == 0 {
+		t.Errorf("Slip44 table is empty")
+	}
+
+	coins, errs = parseSlip44Coins([]byte(`[
+		{"coin_type": 0, "symbol": "BTC", "chains": ["bip122:000000000019d6689c085ae165831e93"]},
+		{"coin_type": 1, "symbol": "BAD", "chains": ["EIP155:1"]},
+		{"coin_type": 60, "symbol": "ETH", "chains": ["eip155:1"]}
+	]`))
+	if len(coins) != 2 || coins[0].CoinType != 0 || coins[1].CoinType != 60 {
+		t.Errorf("Bad rows should be skipped: %+v", coins)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrNamespaceInvalid) {
+		t.Errorf("Bad rows should be reported: %v", errs)
+	}
+
+	if _, errs := parseSlip44Coins([]byte("{")); len(errs) != 1 {
+		t.Errorf("Invalid json should be reported: %v", errs)
+	}
+}
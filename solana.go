@@ -0,0 +1,130 @@
+package caip
+
+import (
+	"fmt"
+)
+
+type solanaNamespace struct{}
+
+func init() {
+	RegisterNamespace("solana", solanaNamespace{})
+}
+
+// Solana chain references are the first 32 characters of the base58 encoded
+// genesis hash.
+func (solanaNamespace) ValidateReference(reference string) error {
+	if len(reference) != 32 {
+		return fmt.Errorf("%w: invalid solana genesis hash: %s", ErrReferenceInvalid, reference)
+	}
+
+	if _, err := base58Decode(reference); err != nil {
+		return fmt.Errorf("%w: invalid solana genesis hash: %s", ErrReferenceInvalid, reference)
+	}
+
+	return nil
+}
+
+func (solanaNamespace) ValidateAddress(chainID ChainID, address string) error {
+	if _, err := solanaPublicKey(address); err != nil {
+		return fmt.Errorf("%w: %s", ErrAddressInvalid, err)
+	}
+
+	return nil
+}
+
+func (n solanaNamespace) NormalizeAddress(chainID ChainID, address string) (string, error) {
+	if err := n.ValidateAddress(chainID, address); err != nil {
+		return "", err
+	}
+
+	return address, nil
+}
+
+func (solanaNamespace) ValidateAsset(a AssetID) error {
+	if a.Namespace != "token" {
+		return nil
+	}
+
+	if _, err := solanaPublicKey(a.Reference); err != nil {
+		return fmt.Errorf("%w: %s", ErrReferenceInvalid, err)
+	}
+
+	if a.TokenID != "" {
+		return fmt.Errorf("%w: unexpected token id: %s", ErrTokenIDInvalid, a.TokenID)
+	}
+
+	return nil
+}
+
+func solanaPublicKey(s string) ([]byte, error) {
+	b, err := base58Decode(s)
+	if err != nil || len(b) != 32 {
+		return nil, fmt.Errorf("invalid solana address: %s", s)
+	}
+
+	return b, nil
+}
+
+type SolanaAccountID struct {
+	AccountID
+}
+
+func NewSolanaAccountID(chainID ChainID, address string) (SolanaAccountID, error) {
+	aID := SolanaAccountID{AccountID{chainID, address}}
+	if err := aID.Validate(); err != nil {
+		return SolanaAccountID{}, err
+	}
+
+	return aID, nil
+}
+
+func UnsafeSolanaAccountID(chainID ChainID, address string) SolanaAccountID {
+	return SolanaAccountID{AccountID{chainID, address}}
+}
+
+func (a SolanaAccountID) Validate() error {
+	if _, err := solanaPublicKey(a.Address); err != nil {
+		return err
+	}
+
+	if a.ChainID.Namespace != "solana" {
+		return fmt.Errorf("invalid chain namespace: %s", a.ChainID.Namespace)
+	}
+
+	return a.AccountID.Validate()
+}
+
+func (a *SolanaAccountID) Parse(s string) error {
+	return parseTyped(s, a.AccountID.Parse, a)
+}
+
+func (a *SolanaAccountID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *SolanaAccountID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *SolanaAccountID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AccountID.UnmarshalJSON, a)
+}
+
+func (a *SolanaAccountID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *SolanaAccountID) Scan(src interface{}) error {
+	return scanParse(AccountIDKind, src, a.Parse)
+}
+
+func (a *SolanaAccountID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AccountIDKind, v, a.Parse)
+}
+
+func (a SolanaAccountID) PublicKey() []byte {
+	b, _ := solanaPublicKey(a.Address)
+	return b
+}
@@ -0,0 +1,121 @@
+package caip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSolanaAccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id string
+	}{{
+		// Solana mainnet
+		id: "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+	}, {
+		// Solana devnet
+		id: "solana:EtWTRABZaYq6iMfeYKouRu166VU2xqa1:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+	}} {
+		a := SolanaAccountID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse account id: %v", err)
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		if _, err := NewSolanaAccountID(a.ChainID, a.Address); err != nil {
+			t.Errorf("Failed to create account id from address")
+		}
+
+		if len(a.PublicKey()) != 32 {
+			t.Errorf("Public key invalid: %x", a.PublicKey())
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = SolanaAccountID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled account id invalid")
+		}
+	}
+}
+
+func TestInvalidSolanaAccountID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		err error
+	}{{
+		// Not base58
+		id:  "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb",
+		err: fmt.Errorf("invalid solana address: %s", "0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb"),
+	}, {
+		// Too short
+		id:  "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiB",
+		err: fmt.Errorf("invalid solana address: %s", "7S3P4HxJpyyigGzodYwHtCxZyUQe9JiB"),
+	}, {
+		id:  "eip155:1:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+		err: fmt.Errorf("invalid chain namespace: %s", "eip155"),
+	}} {
+		a := SolanaAccountID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse account id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize account id to string")
+		}
+
+		_, err := NewSolanaAccountID(a.ChainID, a.Address)
+		if err == nil {
+			t.Fatalf("Create account id should error")
+		}
+
+		if err.Error() != tc.err.Error() {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
+
+func TestSolanaNamespace(t *testing.T) {
+	for _, tc := range []struct {
+		id    string
+		parse func(string) error
+		err   error
+	}{{
+		id:    "solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ",
+		parse: new(ChainID).Parse,
+	}, {
+		// Full genesis hash instead of the truncated one
+		id:    "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d",
+		parse: new(ChainID).Parse,
+		err:   ErrReferenceInvalid,
+	}, {
+		id:    "solana:mainnet",
+		parse: new(ChainID).Parse,
+		err:   ErrReferenceInvalid,
+	}, {
+		id:    "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/slip44:501",
+		parse: new(AssetID).Parse,
+	}, {
+		id:    "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+		parse: new(AssetID).Parse,
+	}, {
+		id:    "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G4w",
+		parse: new(AssetID).Parse,
+		err:   ErrReferenceInvalid,
+	}} {
+		if err := tc.parse(tc.id); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected error %v, got %v", tc.id, tc.err, err)
+		}
+	}
+}
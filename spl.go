@@ -0,0 +1,77 @@
+package caip
+
+import (
+	"fmt"
+)
+
+type SPLTokenAssetID struct {
+	AssetID
+}
+
+func NewSPLTokenAssetID(chainID ChainID, namespace, reference string) (SPLTokenAssetID, error) {
+	aID := SPLTokenAssetID{UnsafeAssetID(chainID, namespace, reference)}
+	if err := aID.Validate(); err != nil {
+		return SPLTokenAssetID{}, err
+	}
+
+	return aID, nil
+}
+
+func UnsafeSPLTokenAssetID(chainID ChainID, namespace, reference string) SPLTokenAssetID {
+	return SPLTokenAssetID{UnsafeAssetID(chainID, namespace, reference)}
+}
+
+func (a SPLTokenAssetID) Validate() error {
+	if _, err := solanaPublicKey(a.Reference); err != nil {
+		return err
+	}
+
+	if a.ChainID.Namespace != "solana" {
+		return fmt.Errorf("invalid chain namespace: %s", a.ChainID.Namespace)
+	}
+
+	if a.AssetID.Namespace != "token" {
+		return fmt.Errorf("invalid asset namespace: %s", a.AssetID.Namespace)
+	}
+
+	return a.AssetID.Validate()
+}
+
+func (a *SPLTokenAssetID) Parse(s string) error {
+	return parseTyped(s, a.AssetID.Parse, a)
+}
+
+func (a *SPLTokenAssetID) ParseBytes(b []byte) error {
+	return a.Parse(unsafeString(b))
+}
+
+func (a *SPLTokenAssetID) ParseX(s string) {
+	if err := a.Parse(s); err != nil {
+		panic(err)
+	}
+}
+
+func (a *SPLTokenAssetID) UnmarshalJSON(data []byte) error {
+	return unmarshalTypedJSON(data, a.AssetID.UnmarshalJSON, a)
+}
+
+func (a *SPLTokenAssetID) UnmarshalText(text []byte) error {
+	return unmarshalText(text, a.Parse)
+}
+
+func (a *SPLTokenAssetID) Scan(src interface{}) error {
+	return scanParse(AssetIDKind, src, a.Parse)
+}
+
+func (a *SPLTokenAssetID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(AssetIDKind, v, a.Parse)
+}
+
+func (a SPLTokenAssetID) Mint() []byte {
+	b, _ := solanaPublicKey(a.Reference)
+	return b
+}
+
+func (a SPLTokenAssetID) AccountID() SolanaAccountID {
+	return SolanaAccountID{AccountID{a.ChainID, a.Reference}}
+}
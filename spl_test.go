@@ -0,0 +1,95 @@
+package caip
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestSPLTokenAssetID(t *testing.T) {
+	for _, tc := range []struct {
+		id string
+	}{{
+		// USDC
+		id: "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+	}} {
+		a := SPLTokenAssetID{}
+		if err := a.Parse(tc.id); err != nil {
+			t.Errorf("Failed to parse asset id")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize asset id to string")
+		}
+
+		if _, err := NewSPLTokenAssetID(a.ChainID, a.AssetID.Namespace, a.AssetID.Reference); err != nil {
+			t.Errorf("Failed to create asset id from mint")
+		}
+
+		if len(a.Mint()) != 32 {
+			t.Errorf("Mint invalid: %x", a.Mint())
+		}
+
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("Failed to marshal to json")
+		}
+
+		a = SPLTokenAssetID{}
+		if err := json.Unmarshal(b, &a); err != nil {
+			t.Errorf("Failed to unmarshal to json")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Unmarshalled asset id invalid")
+		}
+
+		a2 := SPLTokenAssetID{}
+		if err := a2.Scan(a.String()); err != nil {
+			t.Errorf("Scanning value from sql.NullString")
+		}
+
+		if a2.String() != a.String() {
+			t.Errorf("Scanned value not valid")
+		}
+	}
+}
+
+func TestInvalidSPLTokenAssetID(t *testing.T) {
+	for _, tc := range []struct {
+		id  string
+		err error
+	}{{
+		id:  "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G40",
+		err: fmt.Errorf("invalid solana address: %s", "EPjFWdd5AufqSSqeM2qN1xzybapC8G40"),
+	}, {
+		id:  "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp/nft:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+		err: fmt.Errorf("invalid asset namespace: %s", "nft"),
+	}, {
+		id:  "cosmos:cosmoshub-3/token:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+		err: fmt.Errorf("invalid chain namespace: %s", "cosmos"),
+	}} {
+		a := SPLTokenAssetID{}
+		if err := a.Parse(tc.id); err == nil {
+			t.Errorf("Parse asset id should error")
+		}
+
+		if a.String() != tc.id {
+			t.Errorf("Failed to serialize asset id to string")
+		}
+
+		err := a.Validate()
+		if err == nil {
+			t.Fatalf("Validate asset id should error")
+		}
+
+		_, err = NewSPLTokenAssetID(a.ChainID, a.AssetID.Namespace, a.AssetID.Reference)
+		if err == nil {
+			t.Fatalf("Create asset id should error")
+		}
+
+		if err.Error() != tc.err.Error() {
+			t.Errorf("expected error: %s, got: %s", tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package caip
+
+import "fmt"
+
+// scanString converts a database/sql source value to a string. Like
+// database/sql does for string, it rejects NULL; nullable columns are scanned
+// into NullChainID, NullAccountID or NullAssetID.
+func scanString(kind Kind, src interface{}) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", fmt.Errorf("scanning %s: converting NULL is unsupported, use a Null type", kind)
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("scanning %s: unsupported source type %T", kind, src)
+	}
+}
+
+// scanParse scans a string from src and parses it with parse.
+func scanParse(kind Kind, src interface{}, parse func(string) error) error {
+	s, err := scanString(kind, src)
+	if err != nil {
+		return err
+	}
+
+	return parse(s)
+}
@@ -0,0 +1,21 @@
+package caip
+
+type validator interface {
+	Validate() error
+}
+
+func unmarshalText(text []byte, parse func(string) error) error {
+	return parse(string(text))
+}
+
+// parseTyped parses s with parse, the Parse of the generic identifier embedded
+// in a typed wrapper, and then applies the stricter Validate of the wrapper.
+// The Parse of a wrapper backs all of its decode methods, so none of them
+// skips the wrapper's rules.
+func parseTyped(s string, parse func(string) error, v validator) error {
+	if err := parse(s); err != nil {
+		return err
+	}
+
+	return v.Validate()
+}
@@ -0,0 +1,157 @@
+package caip
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTextMapKeys(t *testing.T) {
+	type config struct {
+		RPC string `json:"rpc"`
+	}
+
+	chains := map[ChainID]config{
+		{"eip155", "1"}: {"https://eth.example"},
+		{"solana", "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"}: {"https://sol.example"},
+	}
+
+	b, err := json.Marshal(chains)
+	if err != nil {
+		t.Fatalf("Failed to marshal map: %v", err)
+	}
+
+	expected := `{"eip155:1":{"rpc":"https://eth.example"},"solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp":{"rpc":"https://sol.example"}}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got: %s", expected, b)
+	}
+
+	chains2 := map[ChainID]config{}
+	if err := json.Unmarshal(b, &chains2); err != nil {
+		t.Fatalf("Failed to unmarshal map: %v", err)
+	}
+
+	if len(chains2) != 2 || chains2[ChainID{"eip155", "1"}].RPC != "https://eth.example" {
+		t.Errorf("Unmarshalled map invalid: %v", chains2)
+	}
+
+	if err := json.Unmarshal([]byte(`{"eip155":{}}`), &chains2); err == nil {
+		t.Errorf("Unmarshal invalid map key should error")
+	}
+
+	accounts := map[EVMAccountID]int{}
+	if err := json.Unmarshal([]byte(`{"eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb":1}`), &accounts); err != nil {
+		t.Fatalf("Failed to unmarshal map: %v", err)
+	}
+
+	for aID := range accounts {
+		if aID.AccountID.Address != "0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb" {
+			t.Errorf("Unmarshalled address not checksummed: %s", aID.AccountID.Address)
+		}
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	for _, tc := range []struct {
+		v   encoding.TextUnmarshaler
+		id  string
+		err bool
+	}{{
+		v:  new(ChainID),
+		id: "eip155:1",
+	}, {
+		v:   new(ChainID),
+		id:  "eip155",
+		err: true,
+	}, {
+		v:  new(AccountID),
+		id: "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp:7S3P4HxJpyyigGzodYwHtCxZyUQe9JiBMHyRWXArAaKv",
+	}, {
+		v:  new(AssetType),
+		id: "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d",
+	}, {
+		v:  new(AssetID),
+		id: "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769",
+	}, {
+		v:  new(EVMAccountID),
+		id: "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+	}, {
+		v:   new(EVMAccountID),
+		id:  "cosmos:cosmoshub-3:cosmos1t2uflqwqe0fsj0shcfkrvpukewcw40yjj6hdc0",
+		err: true,
+	}, {
+		v:  new(ERC20AssetID),
+		id: "eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f",
+	}, {
+		v:   new(ERC20AssetID),
+		id:  "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d",
+		err: true,
+	}, {
+		v:  new(ERC721AssetID),
+		id: "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769",
+	}, {
+		v:   new(ERC1155AssetID),
+		id:  "eip155:1/erc721:0x06012c8cf97BEaD5deAe237070F9587f8E7A266d/771769",
+		err: true,
+	}, {
+		v:   new(SolanaAccountID),
+		id:  "eip155:1:0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb",
+		err: true,
+	}, {
+		v:   new(Slip44AssetID),
+		id:  "eip155:1/erc20:0x6b175474e89094c44da98b954eedeac495271d0f",
+		err: true,
+	}} {
+		err := tc.v.UnmarshalText([]byte(tc.id))
+		if tc.err && err == nil {
+			t.Errorf("%T %s: unmarshal text should error", tc.v, tc.id)
+		}
+
+		if !tc.err && err != nil {
+			t.Errorf("%T %s: failed to unmarshal text: %v", tc.v, tc.id, err)
+		}
+
+		if m, ok := tc.v.(encoding.TextMarshaler); ok && !tc.err {
+			text, err := m.MarshalText()
+			if err != nil || string(text) != tc.id {
+				t.Errorf("%T: marshal text invalid: %s, %v", tc.v, text, err)
+			}
+		}
+
+		// The other decode paths agree with UnmarshalText
+		quoted, _ := json.Marshal(tc.id)
+		for name, decode := range map[string]func(interface{}) error{
+			"json": func(v interface{}) error { return json.Unmarshal(quoted, v) },
+			"scan": func(v interface{}) error { return v.(sql.Scanner).Scan(tc.id) },
+			"gql":  func(v interface{}) error { return v.(interface{ UnmarshalGQL(interface{}) error }).UnmarshalGQL(tc.id) },
+		} {
+			v := reflect.New(reflect.TypeOf(tc.v).Elem()).Interface()
+			if err := decode(v); (err != nil) != tc.err {
+				t.Errorf("%T %s: unexpected %s result: %v", tc.v, tc.id, name, err)
+			}
+		}
+	}
+}
+
+func TestTypedJSONObject(t *testing.T) {
+	data := []byte(`{"chain_id":{"namespace":"eip155","reference":"1"},"account_address":"0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb"}`)
+
+	if err := json.Unmarshal(data, new(SolanaAccountID)); err == nil {
+		t.Errorf("Unmarshal eip155 account into solana account id should error")
+	}
+
+	a := EVMAccountID{}
+	if err := json.Unmarshal(data, &a); err != nil {
+		t.Fatalf("Failed to unmarshal to json: %v", err)
+	}
+
+	if a.AccountID.Address != "0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb" {
+		t.Errorf("Unmarshalled address not checksummed: %s", a.AccountID.Address)
+	}
+
+	if err := a.Scan("eip155:1:0xab16a96d359ec26a11e2c2b3d8f8b8942d5bfcdb"); err != nil || a.AccountID.Address != "0xab16a96D359eC26a11e2C2b3d8f8B8942d5Bfcdb" {
+		t.Errorf("Scanned address not checksummed: %s, %v", a.AccountID.Address, err)
+	}
+}